@@ -0,0 +1,139 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor recorded in history.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorOf(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// HistoryRecord is a change of one entity.
+// Before and After are the entity in JSON, empty when unavailable,
+// and Diff is a JSON object mapping the changed fields to [old, new].
+type HistoryRecord struct {
+	EntityId  any
+	Operation string
+	Before    string
+	After     string
+	Diff      string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// HistoryStore saves the records in the transaction of ctx,
+// so that the history is consistent with the data.
+type HistoryStore interface {
+	Save(ctx context.Context, records ...HistoryRecord) error
+	History(ctx context.Context, id any) ([]HistoryRecord, error)
+}
+
+type AuditedDataAccess[E Entity] interface {
+	TxDataAccess[E]
+	// History returns the records of the entity in the order of changed.
+	History(ctx context.Context, id any) ([]HistoryRecord, error)
+}
+
+type auditedDataAccess[E Entity] struct {
+	*capturedDataAccess[E]
+	store HistoryStore
+}
+
+// NewAuditedDataAccess decorates the dataAccess to record
+// the history of each entity written into the store.
+func NewAuditedDataAccess[E Entity](dataAccess TxDataAccess[E], store HistoryStore) AuditedDataAccess[E] {
+	return &auditedDataAccess[E]{
+		capturedDataAccess: newCapturedDataAccess(dataAccess, func(ctx context.Context, record ChangeRecord) error {
+			return store.Save(ctx, BuildHistoryRecords(ctx, record)...)
+		}),
+		store: store,
+	}
+}
+
+func (da *auditedDataAccess[E]) History(ctx context.Context, id any) ([]HistoryRecord, error) {
+	return da.store.History(ctx, id)
+}
+
+// BuildHistoryRecords splits the change into records per entity,
+// matching the entities before and after by id.
+// Entities without id are not matched.
+func BuildHistoryRecords(ctx context.Context, change ChangeRecord) []HistoryRecord {
+	now := time.Now()
+	actor := ActorOf(ctx)
+	records := make([]HistoryRecord, 0, len(change.Before)+len(change.After))
+	index := map[string]int{}
+	find := func(entity any) *HistoryRecord {
+		id := entity.(Entity).GetId()
+		key := fmt.Sprint(id)
+		if i, ok := index[key]; ok {
+			return &records[i]
+		}
+		if id != nil && !reflect.ValueOf(id).IsZero() {
+			index[key] = len(records)
+		}
+		records = append(records, HistoryRecord{EntityId: id, Operation: change.Operation, Actor: actor, CreatedAt: now})
+		return &records[len(records)-1]
+	}
+	for _, entity := range change.Before {
+		find(entity).Before = toJson(entity)
+	}
+	for _, entity := range change.After {
+		find(entity).After = toJson(entity)
+	}
+	for i := range records {
+		records[i].Diff = diffJson(records[i].Before, records[i].After)
+	}
+	return records
+}
+
+func toJson(entity any) string {
+	data, err := json.Marshal(entity)
+	if HasError(err) {
+		return ""
+	}
+	return string(data)
+}
+
+func diffJson(before, after string) string {
+	oldMap, newMap := map[string]any{}, map[string]any{}
+	if before != "" {
+		_ = json.Unmarshal([]byte(before), &oldMap)
+	}
+	if after != "" {
+		_ = json.Unmarshal([]byte(after), &newMap)
+	}
+	diff := map[string][2]any{}
+	for key, oldValue := range oldMap {
+		if newValue := newMap[key]; !reflect.DeepEqual(oldValue, newValue) {
+			diff[key] = [2]any{oldValue, newValue}
+		}
+	}
+	for key, newValue := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			diff[key] = [2]any{nil, newValue}
+		}
+	}
+	return toJson(diff)
+}
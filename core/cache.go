@@ -0,0 +1,254 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheStore stores the query results of a cached DataAccess.
+// Keys of the same entity type share one namespace,
+// which is dropped by Invalidate after the entity type is written.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (any, bool)
+	Set(ctx context.Context, key string, value any, ttl time.Duration)
+	Invalidate(ctx context.Context, namespace string)
+}
+
+// CacheScoper is implemented by the DataAccess whose results vary
+// with ctx beyond the query and the tenant, e.g., by the table
+// resolved for ctx, and the scope is a part of the cache key.
+type CacheScoper interface {
+	CacheScope(ctx context.Context) string
+}
+
+// CacheScopeOf returns the cache scope of the dataAccess for ctx,
+// or an empty string when the dataAccess is not a CacheScoper.
+func CacheScopeOf(ctx context.Context, dataAccess any) string {
+	if scoper, ok := dataAccess.(CacheScoper); ok {
+		return scoper.CacheScope(ctx)
+	}
+	return ""
+}
+
+type cachedDataAccess[E Entity] struct {
+	DataAccess[E]
+	store     CacheStore
+	ttl       time.Duration
+	namespace string
+}
+
+// NewCachedDataAccess decorates the dataAccess to cache the results
+// of Get, Query, Count and Page in the store for ttl.
+// The cache is bypassed inside a transaction found by GetTransaction,
+// and the tenant in ctx is a part of the cache key.
+func NewCachedDataAccess[E Entity](dataAccess DataAccess[E], store CacheStore, ttl time.Duration) DataAccess[E] {
+	return &cachedDataAccess[E]{
+		DataAccess: dataAccess,
+		store:      store,
+		ttl:        ttl,
+		namespace:  reflect.TypeOf(*new(E)).String(),
+	}
+}
+
+func loadCached[E Entity, R any](
+	c *cachedDataAccess[E], ctx context.Context,
+	op string, arg any, load func() (R, error),
+) (R, error) {
+	if _, ok := GetTransaction(ctx); ok {
+		return load()
+	}
+	key := c.namespace + ":" + op + ":" + EncodeQuery(arg)
+	if tenant, bypass, err := ResolveTenant(ctx); err == nil {
+		key += ":" + EncodeQuery(tenant) + fmt.Sprint(bypass)
+	}
+	if scope := CacheScopeOf(ctx, c.DataAccess); scope != "" {
+		key += ":" + scope
+	}
+	if v, ok := c.store.Get(ctx, key); ok {
+		return v.(R), nil
+	}
+	r, err := load()
+	if err == nil {
+		c.store.Set(ctx, key, r, c.ttl)
+	}
+	return r, err
+}
+
+func (c *cachedDataAccess[E]) Get(ctx context.Context, id any) (*E, error) {
+	e, err := loadCached(c, ctx, "Get", id, func() (*E, error) {
+		return c.DataAccess.Get(ctx, id)
+	})
+	if e != nil {
+		e0 := *e
+		e = &e0
+	}
+	return e, err
+}
+
+func (c *cachedDataAccess[E]) Query(ctx context.Context, query Query) ([]E, error) {
+	entities, err := loadCached(c, ctx, "Query", query, func() ([]E, error) {
+		return c.DataAccess.Query(ctx, query)
+	})
+	return append([]E{}, entities...), err
+}
+
+func (c *cachedDataAccess[E]) Count(ctx context.Context, query Query) (int64, error) {
+	return loadCached(c, ctx, "Count", query, func() (int64, error) {
+		return c.DataAccess.Count(ctx, query)
+	})
+}
+
+func (c *cachedDataAccess[E]) Page(ctx context.Context, query Query) (PageList[E], error) {
+	page, err := loadCached(c, ctx, "Page", query, func() (PageList[E], error) {
+		return c.DataAccess.Page(ctx, query)
+	})
+	page.List = append([]E{}, page.List...)
+	return page, err
+}
+
+func (c *cachedDataAccess[E]) Delete(ctx context.Context, id any) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.Delete(ctx, id)
+}
+
+func (c *cachedDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.DeleteByQuery(ctx, query)
+}
+
+func (c *cachedDataAccess[E]) Create(ctx context.Context, entity *E) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.Create(ctx, entity)
+}
+
+func (c *cachedDataAccess[E]) CreateMulti(ctx context.Context, entities []E) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.CreateMulti(ctx, entities)
+}
+
+func (c *cachedDataAccess[E]) Update(ctx context.Context, entity E) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.Update(ctx, entity)
+}
+
+func (c *cachedDataAccess[E]) Patch(ctx context.Context, entity E) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.Patch(ctx, entity)
+}
+
+func (c *cachedDataAccess[E]) PatchByQuery(ctx context.Context, entity E, query Query) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.DataAccess.PatchByQuery(ctx, entity, query)
+}
+
+// asStringer returns the value as fmt.Stringer
+// for both value and pointer receivers.
+func asStringer(value reflect.Value) (fmt.Stringer, bool) {
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer, true
+	}
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	stringer, ok := ptr.Interface().(fmt.Stringer)
+	return stringer, ok
+}
+
+// invalidate drops the namespace, and drops it again after the transaction
+// commits, since the reads outside the transaction could fill the cache
+// with the data before commit in between.
+func (c *cachedDataAccess[E]) invalidate(ctx context.Context) {
+	c.store.Invalidate(ctx, c.namespace)
+	if tc, ok := GetTransaction(ctx); ok {
+		tc.OnCommit(func() {
+			c.store.Invalidate(ctx, c.namespace)
+		})
+	}
+}
+
+// EncodeQuery encodes the query object canonically:
+// pointers are dereferenced, nil fields are skipped,
+// and map keys are sorted, so equal queries share one encoding.
+func EncodeQuery(query any) string {
+	sb := &strings.Builder{}
+	if query != nil {
+		queryType := reflect.TypeOf(query)
+		if queryType.Kind() == reflect.Ptr {
+			queryType = queryType.Elem()
+		}
+		sb.WriteString(queryType.String())
+		encodeValue(sb, reflect.ValueOf(query))
+	}
+	return sb.String()
+}
+
+func encodeValue(sb *strings.Builder, value reflect.Value) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if stringer, ok := asStringer(value); ok {
+			sb.WriteString(stringer.String())
+			return
+		}
+		sb.WriteString("{")
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			fv := value.Field(i)
+			if !field.IsExported() || isNilValue(fv) {
+				continue
+			}
+			sb.WriteString(field.Name)
+			sb.WriteString(":")
+			encodeValue(sb, fv)
+			sb.WriteString(",")
+		}
+		sb.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		sb.WriteString("[")
+		for i := 0; i < value.Len(); i++ {
+			encodeValue(sb, value.Index(i))
+			sb.WriteString(",")
+		}
+		sb.WriteString("]")
+	case reflect.Map:
+		entries := make([]string, 0, value.Len())
+		for _, k := range value.MapKeys() {
+			entry := &strings.Builder{}
+			encodeValue(entry, k)
+			entry.WriteString(":")
+			encodeValue(entry, value.MapIndex(k))
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+		sb.WriteString("map[" + strings.Join(entries, ",") + "]")
+	default:
+		sb.WriteString(fmt.Sprintf("%q", fmt.Sprint(value.Interface())))
+	}
+}
+
+func isNilValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return value.IsNil()
+	}
+	return false
+}
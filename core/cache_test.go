@@ -0,0 +1,214 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type testEntity struct {
+	IntId
+	Name *string
+}
+
+type testQuery struct {
+	PageQuery
+	NameLike *string
+	IdIn     *[]int
+}
+
+type countingDataAccess struct {
+	DataAccess[testEntity]
+	calls int
+	query Query
+}
+
+func (da *countingDataAccess) Get(_ context.Context, id any) (*testEntity, error) {
+	da.calls++
+	return &testEntity{IntId: NewIntId(id.(int)), Name: P("test")}, nil
+}
+
+func (da *countingDataAccess) Query(_ context.Context, query Query) ([]testEntity, error) {
+	da.calls++
+	da.query = query
+	return []testEntity{{IntId: NewIntId(1)}}, nil
+}
+
+func (da *countingDataAccess) Count(context.Context, Query) (int64, error) {
+	da.calls++
+	return 1, nil
+}
+
+func (da *countingDataAccess) Delete(context.Context, any) (int64, error) {
+	return 1, nil
+}
+
+type scopedDataAccess struct {
+	countingDataAccess
+}
+
+func (da *scopedDataAccess) CacheScope(ctx context.Context) string {
+	return fmt.Sprint(ctx.Value("table"))
+}
+
+type testTransactionContext struct {
+	context.Context
+}
+
+func (t *testTransactionContext) Commit() error           { return nil }
+func (t *testTransactionContext) Rollback() error         { return nil }
+func (t *testTransactionContext) Parent() context.Context { return t.Context }
+func (t *testTransactionContext) SavePoint(string) error  { return nil }
+func (t *testTransactionContext) RollbackTo(string) error { return nil }
+func (t *testTransactionContext) OnCommit(func())         {}
+func (t *testTransactionContext) OnRollback(func())       {}
+
+func (t *testTransactionContext) Value(key any) any {
+	if key == TransactionKey {
+		return t
+	}
+	return t.Context.Value(key)
+}
+
+type stringerQuery struct {
+	PageQuery
+	Name string
+}
+
+func (q *stringerQuery) String() string {
+	return "name=" + q.Name
+}
+
+func TestCachedDataAccess(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should hit cache for the same query", func(t *testing.T) {
+		delegate := &countingDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Minute)
+
+		_, _ = da.Query(ctx, testQuery{NameLike: P("te"), IdIn: &[]int{1, 2}})
+		_, _ = da.Query(ctx, &testQuery{NameLike: P("te"), IdIn: &[]int{1, 2}})
+		_, _ = da.Count(ctx, testQuery{NameLike: P("te")})
+		_, _ = da.Count(ctx, testQuery{NameLike: P("te")})
+		_, _ = da.Query(ctx, testQuery{NameLike: P("es")})
+
+		if delegate.calls != 3 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 3, delegate.calls)
+		}
+	})
+
+	t.Run("Should invalidate cache after write", func(t *testing.T) {
+		delegate := &countingDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Minute)
+
+		e1, _ := da.Get(ctx, 1)
+		e1.Name = P("changed")
+		e2, _ := da.Get(ctx, 1)
+		_, _ = da.Delete(ctx, 1)
+		_, _ = da.Get(ctx, 1)
+
+		if *e2.Name != "test" {
+			t.Errorf("Cached entity should not be modified: %s", *e2.Name)
+		}
+		if delegate.calls != 2 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 2, delegate.calls)
+		}
+	})
+
+	t.Run("Should bypass cache in transaction", func(t *testing.T) {
+		delegate := &countingDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Minute)
+		tc := &testTransactionContext{ctx}
+
+		_, _ = da.Count(tc, testQuery{})
+		_, _ = da.Count(tc, testQuery{})
+		derived := context.WithValue(tc, "key", "value")
+		_, _ = da.Count(derived, testQuery{})
+
+		if delegate.calls != 3 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 3, delegate.calls)
+		}
+	})
+
+	t.Run("Should use cache when transaction is suspended", func(t *testing.T) {
+		delegate := &countingDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Minute)
+		nc := &NonTransactionContext{Context: &testTransactionContext{ctx}}
+
+		_, _ = da.Count(nc, testQuery{})
+		_, _ = da.Count(nc, testQuery{})
+
+		if delegate.calls != 1 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 1, delegate.calls)
+		}
+	})
+
+	t.Run("Should separate cache by the scope of DataAccess", func(t *testing.T) {
+		delegate := &scopedDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Minute)
+
+		_, _ = da.Count(context.WithValue(ctx, "table", "t_user_2023"), testQuery{})
+		_, _ = da.Count(context.WithValue(ctx, "table", "t_user_2024"), testQuery{})
+		_, _ = da.Count(context.WithValue(ctx, "table", "t_user_2024"), testQuery{})
+
+		if delegate.calls != 2 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 2, delegate.calls)
+		}
+	})
+
+	t.Run("Should expire entries by ttl", func(t *testing.T) {
+		delegate := &countingDataAccess{}
+		da := NewCachedDataAccess[testEntity](delegate, NewLRUStore(10), time.Nanosecond)
+
+		_, _ = da.Count(ctx, testQuery{})
+		time.Sleep(time.Millisecond)
+		_, _ = da.Count(ctx, testQuery{})
+
+		if delegate.calls != 2 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 2, delegate.calls)
+		}
+	})
+}
+
+func TestEncodeQuery(t *testing.T) {
+	t.Run("Encode Stringer with pointer receiver", func(t *testing.T) {
+		expect := "core.stringerQueryname=test"
+		for _, query := range []any{stringerQuery{Name: "test"}, &stringerQuery{Name: "test"}} {
+			actual := EncodeQuery(query)
+			if actual != expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+			}
+		}
+	})
+}
+
+func TestLRUStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUStore(2)
+	store.Set(ctx, "a:1", 1, 0)
+	store.Set(ctx, "a:2", 2, 0)
+	store.Get(ctx, "a:1")
+	store.Set(ctx, "b:3", 3, 0)
+
+	if _, ok := store.Get(ctx, "a:2"); ok {
+		t.Error("Least recently used entry should be evicted")
+	}
+	store.Invalidate(ctx, "a")
+	if _, ok := store.Get(ctx, "a:1"); ok {
+		t.Error("Entry should be invalidated")
+	}
+	if v, ok := store.Get(ctx, "b:3"); !ok || v != 3 {
+		t.Errorf("\nExpected: %d\nBut got : %v", 3, v)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// ChangeRecord describes a write to the entities.
+// Operation is one of Create, Update and Delete.
+// Before and After hold the entities where available.
+type ChangeRecord struct {
+	EntityType reflect.Type
+	Operation  string
+	Ids        []any
+	Before     []any
+	After      []any
+}
+
+type ChangeSubscriber func(ctx context.Context, record ChangeRecord)
+
+type ChangeFeed struct {
+	mu          sync.RWMutex
+	sn          int
+	subscribers map[int]ChangeSubscriber
+}
+
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{subscribers: map[int]ChangeSubscriber{}}
+}
+
+// Subscribe registers the subscriber and returns the function to unsubscribe.
+func (f *ChangeFeed) Subscribe(subscriber ChangeSubscriber) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sn++
+	sn := f.sn
+	f.subscribers[sn] = subscriber
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscribers, sn)
+	}
+}
+
+// Emit delivers the record to the subscribers after the transaction
+// of ctx commits, or immediately when ctx is not a TransactionContext.
+func (f *ChangeFeed) Emit(ctx context.Context, record ChangeRecord) {
+	if tc, ok := GetTransaction(ctx); ok {
+		tc.OnCommit(func() {
+			f.deliver(SuspendTransaction(ctx), record)
+		})
+	} else {
+		f.deliver(ctx, record)
+	}
+}
+
+func (f *ChangeFeed) deliver(ctx context.Context, record ChangeRecord) {
+	f.mu.RLock()
+	subscribers := make([]ChangeSubscriber, 0, len(f.subscribers))
+	for _, subscriber := range f.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	f.mu.RUnlock()
+	for _, subscriber := range subscribers {
+		subscriber(ctx, record)
+	}
+}
+
+type capturedDataAccess[E Entity] struct {
+	TxDataAccess[E]
+	sink       func(ctx context.Context, record ChangeRecord) error
+	entityType reflect.Type
+}
+
+// NewChangeCapturedDataAccess decorates the dataAccess to emit
+// a ChangeRecord to the feed for each write affecting entities.
+// The entities before the write are loaded in the same transaction,
+// and the entities after Update, Patch and PatchByQuery are reloaded by id.
+// The writes join the transaction of ctx or start a new one,
+// so the database is required to support transactions.
+func NewChangeCapturedDataAccess[E Entity](dataAccess TxDataAccess[E], feed *ChangeFeed) TxDataAccess[E] {
+	return newCapturedDataAccess(dataAccess, func(ctx context.Context, record ChangeRecord) error {
+		feed.Emit(ctx, record)
+		return nil
+	})
+}
+
+func newCapturedDataAccess[E Entity](
+	dataAccess TxDataAccess[E], sink func(ctx context.Context, record ChangeRecord) error,
+) *capturedDataAccess[E] {
+	return &capturedDataAccess[E]{
+		TxDataAccess: dataAccess,
+		sink:         sink,
+		entityType:   reflect.TypeOf(*new(E)),
+	}
+}
+
+func (da *capturedDataAccess[E]) CacheScope(ctx context.Context) string {
+	return CacheScopeOf(ctx, da.TxDataAccess)
+}
+
+// capture runs the write in the transaction of ctx and passes the record
+// built by the write to the sink when any entity is affected.
+// A transaction is started when ctx has none, so the database
+// is required to support transactions, e.g., a replica set for MongoDB.
+// ctx is kept for the write and the sink when it is derived from
+// a TransactionContext, so that the values on top of it, e.g.,
+// the tenant and the actor, still apply.
+func (da *capturedDataAccess[E]) capture(
+	ctx context.Context, operation string,
+	write func(ctx context.Context, record *ChangeRecord) (int64, error),
+) (int64, error) {
+	if _, ok := GetTransaction(ctx); ok {
+		return da.captureIn(ctx, operation, write)
+	}
+	var cnt int64
+	err := da.SubmitTransaction(ctx, func(tc TransactionContext) (err error) {
+		cnt, err = da.captureIn(tc, operation, write)
+		return
+	})
+	return cnt, err
+}
+
+func (da *capturedDataAccess[E]) captureIn(
+	ctx context.Context, operation string,
+	write func(ctx context.Context, record *ChangeRecord) (int64, error),
+) (int64, error) {
+	record := ChangeRecord{EntityType: da.entityType, Operation: operation}
+	cnt, err := write(ctx, &record)
+	if err == nil && cnt > 0 {
+		err = da.sink(ctx, record)
+	}
+	return cnt, err
+}
+
+func (da *capturedDataAccess[E]) load(ctx context.Context, id any) ([]any, error) {
+	e, err := da.TxDataAccess.Get(ctx, id)
+	if HasError(err) || e == nil {
+		return nil, err
+	}
+	return []any{*e}, nil
+}
+
+func (da *capturedDataAccess[E]) loadByQuery(ctx context.Context, query Query) ([]any, []any, error) {
+	entities, err := da.TxDataAccess.Query(ctx, query)
+	if HasError(err) {
+		return nil, nil, err
+	}
+	ids, before := make([]any, len(entities)), make([]any, len(entities))
+	for i, e := range entities {
+		ids[i], before[i] = e.GetId(), e
+	}
+	return ids, before, nil
+}
+
+func (da *capturedDataAccess[E]) Create(ctx context.Context, entity *E) (int64, error) {
+	var id int64
+	_, err := da.capture(ctx, "Create", func(ctx context.Context, record *ChangeRecord) (int64, error) {
+		var err error
+		id, err = da.TxDataAccess.Create(ctx, entity)
+		record.Ids, record.After = []any{(*entity).GetId()}, []any{*entity}
+		return 1, err
+	})
+	return id, err
+}
+
+// CreateMulti records the ids assigned to the entities by the batch insert,
+// which are absent for the databases not returning them for a batch.
+func (da *capturedDataAccess[E]) CreateMulti(ctx context.Context, entities []E) (int64, error) {
+	return da.capture(ctx, "Create", func(ctx context.Context, record *ChangeRecord) (int64, error) {
+		cnt, err := da.TxDataAccess.CreateMulti(ctx, entities)
+		if HasError(err) {
+			return 0, err
+		}
+		for _, entity := range entities {
+			if id := entity.GetId(); id != nil && !reflect.ValueOf(id).IsZero() {
+				record.Ids = append(record.Ids, id)
+			}
+			record.After = append(record.After, entity)
+		}
+		return cnt, nil
+	})
+}
+
+func (da *capturedDataAccess[E]) Update(ctx context.Context, entity E) (int64, error) {
+	return da.capture(ctx, "Update", func(ctx context.Context, record *ChangeRecord) (int64, error) {
+		return da.writeById(ctx, record, entity.GetId(), func() (int64, error) {
+			return da.TxDataAccess.Update(ctx, entity)
+		})
+	})
+}
+
+func (da *capturedDataAccess[E]) Patch(ctx context.Context, entity E) (int64, error) {
+	return da.capture(ctx, "Update", func(ctx context.Context, record *ChangeRecord) (int64, error) {
+		return da.writeById(ctx, record, entity.GetId(), func() (int64, error) {
+			return da.TxDataAccess.Patch(ctx, entity)
+		})
+	})
+}
+
+// writeById records the entity before and after the write.
+func (da *capturedDataAccess[E]) writeById(
+	ctx context.Context, record *ChangeRecord, id any, write func() (int64, error),
+) (cnt int64, err error) {
+	record.Ids = []any{id}
+	if record.Before, err = da.load(ctx, id); HasError(err) {
+		return
+	}
+	if cnt, err = write(); HasError(err) {
+		return
+	}
+	record.After, err = da.load(ctx, id)
+	return
+}
+
+func (da *capturedDataAccess[E]) PatchByQuery(ctx context.Context, entity E, query Query) (int64, error) {
+	return da.capture(ctx, "Update", func(ctx context.Context, record *ChangeRecord) (cnt int64, err error) {
+		if record.Ids, record.Before, err = da.loadByQuery(ctx, query); HasError(err) {
+			return
+		}
+		if cnt, err = da.TxDataAccess.PatchByQuery(ctx, entity, query); HasError(err) {
+			return
+		}
+		for _, id := range record.Ids {
+			after, err := da.load(ctx, id)
+			if HasError(err) {
+				return 0, err
+			}
+			record.After = append(record.After, after...)
+		}
+		return
+	})
+}
+
+func (da *capturedDataAccess[E]) Delete(ctx context.Context, id any) (int64, error) {
+	return da.capture(ctx, "Delete", func(ctx context.Context, record *ChangeRecord) (cnt int64, err error) {
+		record.Ids = []any{id}
+		if record.Before, err = da.load(ctx, id); HasError(err) {
+			return
+		}
+		return da.TxDataAccess.Delete(ctx, id)
+	})
+}
+
+func (da *capturedDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (int64, error) {
+	return da.capture(ctx, "Delete", func(ctx context.Context, record *ChangeRecord) (cnt int64, err error) {
+		if record.Ids, record.Before, err = da.loadByQuery(ctx, query); HasError(err) {
+			return
+		}
+		return da.TxDataAccess.DeleteByQuery(ctx, query)
+	})
+}
@@ -12,16 +12,29 @@ package core
 
 import (
 	"fmt"
+	"time"
 )
 
 var Config = struct {
 	TableFormat     string
 	JoinIdFormat    string
 	JoinTableFormat string
+	// TimeLayouts are tried in order to parse the time in parameters.
+	TimeLayouts []string
+	// TimeLocation is the location of the parsed and loaded time,
+	// and of the dates compared with the time columns.
+	// The time is written in UTC for the dialect storing it as text.
+	TimeLocation *time.Location
+	// MaxItems limits the length of the slices in query objects,
+	// unless overridden by the tag `maxItems`, 0 for no limit.
+	MaxItems int
 }{
 	"t_%s",
 	"%s_id",
 	"a_%s_and_%s",
+	[]string{"2006-01-02", time.RFC3339, "2006-01-02 15:04:05"},
+	time.UTC,
+	0,
 }
 
 var m = map[string]string{}
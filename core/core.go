@@ -14,10 +14,41 @@ import (
 	"context"
 	"database/sql/driver"
 	"regexp"
+	"strings"
 )
 
-var SuffixStr = "Gt|Ge|Lt|Le|Not|Ne|Eq|Null|NotIn|In|Like|NotLike|Contain|NotContain|Start|NotStart|End|NotEnd|Rx"
-var SuffixRgx = regexp.MustCompile("(" + SuffixStr + ")$")
+var SuffixStr = "Gt|Ge|Lt|Le|Between|Not|Ne|Eq|Null|NotIn|In|Like|NotLike|Contain|NotContain|Start|NotStart|End|NotEnd|Rx"
+var SuffixRgx = buildSuffixRgx()
+
+// IgnoreCase is appended to a suffix for case-insensitive matching, e.g., `NameContainIgnoreCase`.
+const IgnoreCase = "IgnoreCase"
+
+// RegisterSuffix adds the custom suffixes ahead of the built-in ones
+// and rebuilds SuffixRgx, it should be called at initialization
+// before any query is built.
+func RegisterSuffix(names ...string) {
+	for _, name := range names {
+		if name == "" || containsSuffix(name) {
+			continue
+		}
+		SuffixStr = name + "|" + SuffixStr
+	}
+	SuffixRgx = buildSuffixRgx()
+}
+
+func buildSuffixRgx() *regexp.Regexp {
+	return regexp.MustCompile("(" + SuffixStr + ")(" + IgnoreCase + ")?$")
+}
+
+func containsSuffix(name string) bool {
+	for _, suffix := range strings.Split(SuffixStr, "|") {
+		if suffix == name {
+			return true
+		}
+	}
+	return false
+}
+
 var SortRgx = regexp.MustCompile("(?i)(\\w+)(,(asC|dEsc))?;?")
 
 type PageList[D any] struct {
@@ -29,6 +60,8 @@ type Response struct {
 	Data    any     `json:"data,omitempty"`
 	Success bool    `json:"success"`
 	Error   *string `json:"error,omitempty"`
+	// Errors holds the field errors when the query object is invalid.
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
 type Query interface {
@@ -64,8 +97,8 @@ type DataAccess[E Entity] interface {
 
 type TransactionManager interface {
 	GetClient() any
-	StartTransaction(ctx context.Context) (TransactionContext, error)
-	SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error) error
+	StartTransaction(ctx context.Context, opts ...TxOption) (TransactionContext, error)
+	SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error, opts ...TxOption) error
 }
 
 type TransactionContext interface {
@@ -74,6 +107,10 @@ type TransactionContext interface {
 	Parent() context.Context
 	SavePoint(name string) error
 	RollbackTo(name string) error
+	// OnCommit registers the callback invoked after the transaction is committed.
+	OnCommit(callback func())
+	// OnRollback registers the callback invoked after the transaction is rolled back.
+	OnRollback(callback func())
 }
 
 type TxDataAccess[E Entity] interface {
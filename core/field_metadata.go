@@ -18,6 +18,9 @@ import (
 type FieldMetadata struct {
 	Field      reflect.StructField
 	IsId       bool
+	IsTenant   bool
+	IsJson     bool
+	IsArray    bool
 	ColumnName string
 	EntityPath *EntityPath
 }
@@ -37,20 +40,36 @@ func BuildFieldMetas(structType reflect.Type) []FieldMetadata {
 }
 
 func buildFieldMetadata(field reflect.StructField) []FieldMetadata {
-	if field.Type.Kind() == reflect.Struct {
+	isJson := IsJsonType(field.Tag.Get("type"))
+	if field.Type.Kind() == reflect.Struct && field.Type != TimeType && !isJson {
 		return BuildFieldMetas(field.Type)
 	}
 	cm := FieldMetadata{
 		Field:      field,
 		IsId:       field.Name == "Id",
+		IsJson:     isJson,
+		IsArray:    IsArrayType(field.Tag.Get("type")),
 		ColumnName: ConvertToColumnCase(field.Name),
 	}
+	_, cm.IsTenant = field.Tag.Lookup("tenant")
 	if _, ok := field.Tag.Lookup("entitypath"); ok {
 		cm.EntityPath = BuildEntityPath(field)
 	}
 	return []FieldMetadata{cm}
 }
 
+// IsJsonType reports whether the column type in the tag `type` is JSON,
+// whose field is stored as the marshalled JSON text.
+func IsJsonType(columnType string) bool {
+	return strings.EqualFold(columnType, "JSON") || strings.EqualFold(columnType, "JSONB")
+}
+
+// IsArrayType reports whether the column type in the tag `type` is an array,
+// e.g., `text[]`, whose slice field is stored as the array literal.
+func IsArrayType(columnType string) bool {
+	return strings.HasSuffix(columnType, "[]")
+}
+
 type EntityPath struct {
 	Path       []string
 	Base       Relation
@@ -67,7 +86,7 @@ func BuildEntityPath(field reflect.StructField) *EntityPath {
 	l := len(path)
 	relations := make([]Relation, l-1)
 	for i := 0; i < l-1; i++ {
-		relations[i] = buildRelation(path[i], path[i+1])
+		relations[i] = BuildRelation(path[i], path[i+1])
 	}
 	targetTable := FormatTable(path[l-1])
 	localFieldColumn := ConvertToColumnCase(field.Tag.Get("localField"))
@@ -83,6 +102,6 @@ func BuildEntityPath(field reflect.StructField) *EntityPath {
 }
 
 // e1: left entity, e2: right entity
-func buildRelation(e1 string, e2 string) Relation {
+func BuildRelation(e1 string, e2 string) Relation {
 	return Relation{FormatJoinId(e1), FormatJoinId(e2), FormatJoinTable(e1, e2)}
 }
@@ -0,0 +1,174 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import "context"
+
+// Lifecycle hooks are optional interfaces implemented by the entity.
+// They are called on the pointer of the entity by the data access,
+// and the returned error aborts the operation.
+
+type BeforeCreateHook interface {
+	BeforeCreate(ctx context.Context) error
+}
+
+type AfterCreateHook interface {
+	AfterCreate(ctx context.Context) error
+}
+
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context) error
+}
+
+type AfterQueryHook interface {
+	AfterQuery(ctx context.Context) error
+}
+
+type Hooks struct {
+	BeforeCreate, AfterCreate bool
+	BeforeUpdate, AfterUpdate bool
+	BeforeDelete, AfterDelete bool
+	AfterQuery                bool
+}
+
+// HooksOf reports the lifecycle hooks implemented by the entity type E.
+func HooksOf[E any]() Hooks {
+	e := any(new(E))
+	var hooks Hooks
+	_, hooks.BeforeCreate = e.(BeforeCreateHook)
+	_, hooks.AfterCreate = e.(AfterCreateHook)
+	_, hooks.BeforeUpdate = e.(BeforeUpdateHook)
+	_, hooks.AfterUpdate = e.(AfterUpdateHook)
+	_, hooks.BeforeDelete = e.(BeforeDeleteHook)
+	_, hooks.AfterDelete = e.(AfterDeleteHook)
+	_, hooks.AfterQuery = e.(AfterQueryHook)
+	return hooks
+}
+
+// NeedDeleted reports whether the entities should be loaded before deleted.
+func (h Hooks) NeedDeleted() bool {
+	return h.BeforeDelete || h.AfterDelete
+}
+
+func (h Hooks) HasAfterWrite() bool {
+	return h.AfterCreate || h.AfterUpdate || h.AfterDelete
+}
+
+func CallBeforeCreate[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(BeforeCreateHook); ok {
+			return h.BeforeCreate(ctx)
+		}
+		return nil
+	})
+}
+
+func CallAfterCreate[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(AfterCreateHook); ok {
+			return h.AfterCreate(ctx)
+		}
+		return nil
+	})
+}
+
+func CallBeforeUpdate[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(BeforeUpdateHook); ok {
+			return h.BeforeUpdate(ctx)
+		}
+		return nil
+	})
+}
+
+func CallAfterUpdate[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(AfterUpdateHook); ok {
+			return h.AfterUpdate(ctx)
+		}
+		return nil
+	})
+}
+
+func CallBeforeDelete[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(BeforeDeleteHook); ok {
+			return h.BeforeDelete(ctx)
+		}
+		return nil
+	})
+}
+
+func CallAfterDelete[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(AfterDeleteHook); ok {
+			return h.AfterDelete(ctx)
+		}
+		return nil
+	})
+}
+
+func CallAfterQuery[E any](ctx context.Context, entities ...*E) error {
+	return callHooks(entities, func(e any) error {
+		if h, ok := e.(AfterQueryHook); ok {
+			return h.AfterQuery(ctx)
+		}
+		return nil
+	})
+}
+
+func callHooks[E any](entities []*E, hook func(any) error) error {
+	for _, entity := range entities {
+		if err := hook(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pointers returns the pointers to the elements of entities,
+// so that hooks are able to modify the entities.
+func Pointers[E any](entities []E) []*E {
+	ptrs := make([]*E, len(entities))
+	for i := range entities {
+		ptrs[i] = &entities[i]
+	}
+	return ptrs
+}
+
+// SubmitWithHooks runs the write operation in a transaction when needed,
+// so that the error returned by an after hook rolls back the write.
+func SubmitWithHooks(
+	ctx context.Context, tm TransactionManager, needTx bool,
+	write func(ctx context.Context) (int64, error),
+) (int64, error) {
+	if _, ok := GetTransaction(ctx); ok || !needTx {
+		return write(ctx)
+	}
+	var cnt int64
+	err := tm.SubmitTransaction(ctx, func(tc TransactionContext) (err error) {
+		cnt, err = write(tc)
+		return
+	})
+	return cnt, err
+}
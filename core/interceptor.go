@@ -0,0 +1,150 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"reflect"
+)
+
+// Invocation describes a call to DataAccess.
+// Id is set for Get and Delete, Query for the query methods,
+// and Entity holds *E for Create, Update, Patch and PatchByQuery,
+// or []E for CreateMulti.
+type Invocation struct {
+	Operation  string
+	EntityType reflect.Type
+	Id         any
+	Query      Query
+	Entity     any
+}
+
+type Invoker func(ctx context.Context, inv *Invocation) (any, error)
+
+// Interceptor wraps the invocation of DataAccess.
+// It can modify ctx and inv before calling next,
+// return without calling next to short-circuit the invocation,
+// or observe the result and error returned by next.
+type Interceptor func(ctx context.Context, inv *Invocation, next Invoker) (any, error)
+
+type interceptedDataAccess[E Entity] struct {
+	TxDataAccess[E]
+	entityType reflect.Type
+	invoker    Invoker
+}
+
+// Intercept wraps dataAccess with interceptors,
+// the first interceptor being the outermost one.
+func Intercept[E Entity](dataAccess TxDataAccess[E], interceptors ...Interceptor) TxDataAccess[E] {
+	if len(interceptors) == 0 {
+		return dataAccess
+	}
+	var invoker Invoker = func(ctx context.Context, inv *Invocation) (any, error) {
+		return invoke[E](ctx, dataAccess, inv)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, inv *Invocation) (any, error) {
+			return interceptor(ctx, inv, next)
+		}
+	}
+	return &interceptedDataAccess[E]{
+		TxDataAccess: dataAccess,
+		entityType:   reflect.TypeOf(*new(E)),
+		invoker:      invoker,
+	}
+}
+
+func invoke[E Entity](ctx context.Context, da DataAccess[E], inv *Invocation) (any, error) {
+	switch inv.Operation {
+	case "Get":
+		return da.Get(ctx, inv.Id)
+	case "Delete":
+		return da.Delete(ctx, inv.Id)
+	case "Query":
+		return da.Query(ctx, inv.Query)
+	case "Count":
+		return da.Count(ctx, inv.Query)
+	case "DeleteByQuery":
+		return da.DeleteByQuery(ctx, inv.Query)
+	case "Page":
+		return da.Page(ctx, inv.Query)
+	case "Create":
+		return da.Create(ctx, inv.Entity.(*E))
+	case "CreateMulti":
+		return da.CreateMulti(ctx, inv.Entity.([]E))
+	case "Update":
+		return da.Update(ctx, *inv.Entity.(*E))
+	case "Patch":
+		return da.Patch(ctx, *inv.Entity.(*E))
+	case "PatchByQuery":
+		return da.PatchByQuery(ctx, *inv.Entity.(*E), inv.Query)
+	}
+	panic("unknown operation: " + inv.Operation)
+}
+
+func resultAs[R any](result any, err error) (R, error) {
+	r, _ := result.(R)
+	return r, err
+}
+
+func (da *interceptedDataAccess[E]) call(ctx context.Context, inv Invocation) (any, error) {
+	inv.EntityType = da.entityType
+	return da.invoker(ctx, &inv)
+}
+
+func (da *interceptedDataAccess[E]) Get(ctx context.Context, id any) (*E, error) {
+	return resultAs[*E](da.call(ctx, Invocation{Operation: "Get", Id: id}))
+}
+
+func (da *interceptedDataAccess[E]) Delete(ctx context.Context, id any) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "Delete", Id: id}))
+}
+
+func (da *interceptedDataAccess[E]) Query(ctx context.Context, query Query) ([]E, error) {
+	return resultAs[[]E](da.call(ctx, Invocation{Operation: "Query", Query: query}))
+}
+
+func (da *interceptedDataAccess[E]) Count(ctx context.Context, query Query) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "Count", Query: query}))
+}
+
+func (da *interceptedDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "DeleteByQuery", Query: query}))
+}
+
+func (da *interceptedDataAccess[E]) Page(ctx context.Context, query Query) (PageList[E], error) {
+	return resultAs[PageList[E]](da.call(ctx, Invocation{Operation: "Page", Query: query}))
+}
+
+func (da *interceptedDataAccess[E]) Create(ctx context.Context, entity *E) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "Create", Entity: entity}))
+}
+
+func (da *interceptedDataAccess[E]) CreateMulti(ctx context.Context, entities []E) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "CreateMulti", Entity: entities}))
+}
+
+func (da *interceptedDataAccess[E]) Update(ctx context.Context, entity E) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "Update", Entity: &entity}))
+}
+
+func (da *interceptedDataAccess[E]) Patch(ctx context.Context, entity E) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "Patch", Entity: &entity}))
+}
+
+func (da *interceptedDataAccess[E]) PatchByQuery(ctx context.Context, entity E, query Query) (int64, error) {
+	return resultAs[int64](da.call(ctx, Invocation{Operation: "PatchByQuery", Entity: &entity, Query: query}))
+}
+
+func (da *interceptedDataAccess[E]) CacheScope(ctx context.Context) string {
+	return CacheScopeOf(ctx, da.TxDataAccess)
+}
@@ -0,0 +1,78 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type txCountingDataAccess struct {
+	TransactionManager
+	*countingDataAccess
+}
+
+func TestIntercept(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should call interceptors in order", func(t *testing.T) {
+		var trace []string
+		tracer := func(name string) Interceptor {
+			return func(ctx context.Context, inv *Invocation, next Invoker) (any, error) {
+				trace = append(trace, name+">"+inv.Operation)
+				result, err := next(ctx, inv)
+				trace = append(trace, name+"<"+inv.EntityType.Name())
+				return result, err
+			}
+		}
+		da := Intercept[testEntity](&txCountingDataAccess{nil, &countingDataAccess{}}, tracer("a"), tracer("b"))
+
+		cnt, err := da.Count(ctx, testQuery{})
+
+		expect := []string{"a>Count", "b>Count", "b<testEntity", "a<testEntity"}
+		if !(err == nil && cnt == 1 && reflect.DeepEqual(trace, expect)) {
+			t.Errorf("\nExpected: %v\nBut got : %v", expect, trace)
+		}
+	})
+
+	t.Run("Should modify query before invocation", func(t *testing.T) {
+		delegate := &txCountingDataAccess{nil, &countingDataAccess{}}
+		da := Intercept[testEntity](delegate, func(ctx context.Context, inv *Invocation, next Invoker) (any, error) {
+			query := inv.Query.(testQuery)
+			query.NameLike = P("tenant")
+			inv.Query = query
+			return next(ctx, inv)
+		})
+
+		_, _ = da.Query(ctx, testQuery{})
+
+		actual, ok := delegate.query.(testQuery)
+		if !(delegate.calls == 1 && ok && *actual.NameLike == "tenant") {
+			t.Errorf("Query is not modified: %v", delegate.query)
+		}
+	})
+
+	t.Run("Should short-circuit invocation", func(t *testing.T) {
+		delegate := &txCountingDataAccess{nil, &countingDataAccess{}}
+		denied := errors.New("access denied")
+		da := Intercept[testEntity](delegate, func(ctx context.Context, inv *Invocation, next Invoker) (any, error) {
+			return nil, denied
+		})
+
+		entity, err := da.Get(ctx, 1)
+
+		if !(entity == nil && err == denied && delegate.calls == 0) {
+			t.Errorf("Should be denied: %v, %v", entity, err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key      string
+	value    any
+	expireAt time.Time
+}
+
+type lruStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// NewLRUStore creates an in-memory CacheStore
+// which evicts the least recently used entry beyond capacity.
+func NewLRUStore(capacity int) CacheStore {
+	return &lruStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *lruStore) Get(_ context.Context, key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (s *lruStore) Set(_ context.Context, key string, value any, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return
+	}
+	s.entries[key] = s.order.PushFront(entry)
+	for s.capacity > 0 && s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+}
+
+func (s *lruStore) Invalidate(_ context.Context, namespace string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, elem := range s.entries {
+		if strings.HasPrefix(key, namespace+":") {
+			s.remove(elem)
+		}
+	}
+}
+
+func (s *lruStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*lruEntry).key)
+}
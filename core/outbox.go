@@ -0,0 +1,97 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+var ErrNoTransaction = errors.New("transaction is required")
+
+type OutboxEvent struct {
+	Id        any
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Outbox stores the events in the same transaction with the business data,
+// so that the events are published if and only if the data are committed.
+type Outbox interface {
+	// Append saves the events in the transaction of ctx,
+	// and returns ErrNoTransaction when ctx is not a TransactionContext.
+	Append(ctx context.Context, events ...OutboxEvent) error
+	// Fetch loads the pending events in the order of appended.
+	Fetch(ctx context.Context, limit int) ([]OutboxEvent, error)
+	MarkSent(ctx context.Context, id any) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event OutboxEvent) error
+}
+
+// Relay dispatches the pending events in Outbox to Publisher
+// with at-least-once semantics: an event is marked as sent only
+// after published, and is published again if the marking fails.
+type Relay struct {
+	outbox    Outbox
+	publisher Publisher
+	batchSize int
+	interval  time.Duration
+}
+
+func NewRelay(outbox Outbox, publisher Publisher, batchSize int, interval time.Duration) *Relay {
+	return &Relay{outbox: outbox, publisher: publisher, batchSize: batchSize, interval: interval}
+}
+
+// RelayOnce publishes a batch of the pending events in order,
+// and stops at the first failure to keep the order.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.outbox.Fetch(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("fetch outbox events: %w", err)
+	}
+	for i, event := range events {
+		if err = r.publisher.Publish(ctx, event); err != nil {
+			return i, fmt.Errorf("publish outbox event %v to %s: %w", event.Id, event.Topic, err)
+		}
+		if err = r.outbox.MarkSent(ctx, event.Id); err != nil {
+			return i, fmt.Errorf("mark outbox event %v as sent: %w", event.Id, err)
+		}
+	}
+	return len(events), nil
+}
+
+// Run polls the outbox until ctx is done.
+// The next batch is relayed without waiting when the batch is full,
+// and a failed batch is logged and relayed again after the interval.
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		cnt, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("Failed to relay outbox events")
+		}
+		if cnt < r.batchSize || err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(r.interval):
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
@@ -11,8 +11,8 @@
 package core
 
 type PageQuery struct {
-	PageNumber *int    `json:"page,omitempty"`
-	PageSize   *int    `json:"size,omitempty"`
+	PageNumber *int    `json:"page,omitempty" min:"0"`
+	PageSize   *int    `json:"size,omitempty" min:"0"`
 	Sort       *string `json:"sort,omitempty"`
 }
 
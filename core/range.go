@@ -0,0 +1,33 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+// Range is the value type of a query field with the suffix `Between`,
+// a nil bound leaves that side of the range open.
+type Range[T any] struct {
+	From *T `json:"from,omitempty"`
+	To   *T `json:"to,omitempty"`
+}
+
+// Bounded is implemented by Range to expose its bounds without type parameters.
+type Bounded interface {
+	Bounds() (from any, to any)
+}
+
+func (r Range[T]) Bounds() (from any, to any) {
+	if r.From != nil {
+		from = *r.From
+	}
+	if r.To != nil {
+		to = *r.To
+	}
+	return
+}
@@ -0,0 +1,73 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// RetryChecker is implemented by the TransactionManager which
+// recognizes the retryable errors of its driver, such as
+// serialization failures and deadlocks.
+type RetryChecker interface {
+	IsRetryable(err error) bool
+}
+
+type RetryPolicy struct {
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled for each retry.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retryable overrides the RetryChecker of the TransactionManager.
+	Retryable func(err error) bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     10 * time.Millisecond,
+	MaxBackoff:  time.Second,
+}
+
+// SubmitWithRetry submits the callback in a new TransactionContext
+// for each attempt until it succeeds, a non-retryable error occurs,
+// or the attempts are exhausted.
+// The callback runs once when ctx is already a TransactionContext,
+// since the outer transaction can not be retried here.
+func SubmitWithRetry(
+	ctx context.Context, tm TransactionManager, policy RetryPolicy,
+	callback func(tc TransactionContext) error, opts ...TxOption,
+) error {
+	retryable := policy.Retryable
+	if retryable == nil {
+		if checker, ok := tm.(RetryChecker); ok {
+			retryable = checker.IsRetryable
+		}
+	}
+	if _, ok := GetTransaction(ctx); ok || retryable == nil {
+		return tm.SubmitTransaction(ctx, callback, opts...)
+	}
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := tm.SubmitTransaction(ctx, callback, opts...)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errBusy = errors.New("database is locked")
+
+type retryTransactionManager struct {
+	TransactionManager
+	tcs []TransactionContext
+}
+
+func (tm *retryTransactionManager) SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error, _ ...TxOption) error {
+	tc := &testTransactionContext{ctx}
+	tm.tcs = append(tm.tcs, tc)
+	return callback(tc)
+}
+
+func (tm *retryTransactionManager) IsRetryable(err error) bool {
+	return errors.Is(err, errBusy)
+}
+
+func TestSubmitWithRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	t.Run("Retry in new transaction until success", func(t *testing.T) {
+		tm := &retryTransactionManager{}
+		err := SubmitWithRetry(ctx, tm, policy, func(tc TransactionContext) error {
+			if len(tm.tcs) < 3 {
+				return errBusy
+			}
+			return nil
+		})
+		if !(err == nil && len(tm.tcs) == 3 && tm.tcs[0] != tm.tcs[1]) {
+			t.Errorf("Unexpected: %v, %d", err, len(tm.tcs))
+		}
+	})
+
+	t.Run("Stop when attempts are exhausted", func(t *testing.T) {
+		tm := &retryTransactionManager{}
+		err := SubmitWithRetry(ctx, tm, policy, func(tc TransactionContext) error {
+			return errBusy
+		})
+		if !(err == errBusy && len(tm.tcs) == 3) {
+			t.Errorf("Unexpected: %v, %d", err, len(tm.tcs))
+		}
+	})
+
+	t.Run("Stop for non-retryable error", func(t *testing.T) {
+		tm := &retryTransactionManager{}
+		failed := errors.New("failed")
+		err := SubmitWithRetry(ctx, tm, policy, func(tc TransactionContext) error {
+			return failed
+		})
+		if !(err == failed && len(tm.tcs) == 1) {
+			t.Errorf("Unexpected: %v, %d", err, len(tm.tcs))
+		}
+	})
+
+	t.Run("Run once in outer transaction", func(t *testing.T) {
+		tm := &retryTransactionManager{}
+		err := SubmitWithRetry(&testTransactionContext{ctx}, tm, policy, func(tc TransactionContext) error {
+			return errBusy
+		})
+		if !(err == errBusy && len(tm.tcs) == 1) {
+			t.Errorf("Unexpected: %v, %d", err, len(tm.tcs))
+		}
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var ErrTenantMissing = errors.New("tenant is missing in context")
+
+type tenantKey struct{}
+type tenantBypassKey struct{}
+
+// WithTenant returns a copy of ctx carrying the tenant,
+// which is used to filter and fill the field tagged by `tenant`.
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// WithoutTenant returns a copy of ctx bypassing the tenant filter,
+// which is intended for administrative operations across tenants.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantBypassKey{}, true)
+}
+
+// ResolveTenant reads the tenant from ctx.
+// bypass is true when ctx is created by WithoutTenant.
+func ResolveTenant(ctx context.Context) (tenant any, bypass bool, err error) {
+	if ctx.Value(tenantBypassKey{}) == true {
+		return nil, true, nil
+	}
+	if tenant = ctx.Value(tenantKey{}); tenant == nil {
+		return nil, false, ErrTenantMissing
+	}
+	return tenant, false, nil
+}
+
+// AssignTenant sets the tenant to the field,
+// which could be a pointer to the type of tenant.
+func AssignTenant(field reflect.Value, tenant any) {
+	value := reflect.ValueOf(tenant)
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(value.Convert(field.Type().Elem()))
+		field.Set(ptr)
+	} else {
+		field.Set(value.Convert(field.Type()))
+	}
+}
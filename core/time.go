@@ -0,0 +1,74 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var TimeType = reflect.TypeOf(time.Time{})
+
+var now = time.Now
+
+var relativeTimeRgx = regexp.MustCompile(`^now(([+-])(\d+)([smhdw]))?$`)
+
+// ParseTime parses s by Config.TimeLayouts in Config.TimeLocation,
+// or a relative expression like `now`, `now-7d` and `now+2h`
+// with the units s, m, h, d and w.
+func ParseTime(s string) (time.Time, error) {
+	if match := relativeTimeRgx.FindStringSubmatch(s); len(match) > 0 {
+		return parseRelativeTime(match)
+	}
+	for _, layout := range Config.TimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, Config.TimeLocation); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unsupported time: " + s)
+}
+
+func parseRelativeTime(match []string) (time.Time, error) {
+	t := now().In(Config.TimeLocation)
+	if match[1] == "" {
+		return t, nil
+	}
+	n, err := strconv.Atoi(match[3])
+	if err != nil {
+		return t, err
+	}
+	if match[2] == "-" {
+		n = -n
+	}
+	switch match[4] {
+	case "w":
+		return t.AddDate(0, 0, 7*n), nil
+	case "d":
+		return t.AddDate(0, 0, n), nil
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour), nil
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute), nil
+	default:
+		return t.Add(time.Duration(n) * time.Second), nil
+	}
+}
+
+// IsTimeType reports whether t is time.Time, *time.Time or Range[time.Time].
+func IsTimeType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t == TimeType || t == reflect.TypeOf(Range[time.Time]{})
+}
@@ -0,0 +1,62 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	fixed := time.Date(2024, 3, 10, 8, 30, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		input  string
+		expect time.Time
+	}{
+		{"2024-01-01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-01 12:05:00", time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)},
+		{"2024-01-01T12:05:00+08:00", time.Date(2024, 1, 1, 4, 5, 0, 0, time.UTC)},
+		{"now", fixed},
+		{"now-7d", time.Date(2024, 3, 3, 8, 30, 0, 0, time.UTC)},
+		{"now+2h", time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)},
+		{"now-1w", time.Date(2024, 3, 3, 8, 30, 0, 0, time.UTC)},
+		{"now-15m", time.Date(2024, 3, 10, 8, 15, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, err := ParseTime(tt.input)
+			if err != nil || !actual.Equal(tt.expect) {
+				t.Errorf("\nExpected: %s\nBut got : %s, %v", tt.expect, actual, err)
+			}
+		})
+	}
+
+	t.Run("Parse date in configured location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+8", 8*3600)
+		Config.TimeLocation = loc
+		defer func() { Config.TimeLocation = time.UTC }()
+
+		actual, _ := ParseTime("2024-01-01")
+		expect := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+		if !actual.Equal(expect) || actual.Location() != loc {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Return error for unsupported time", func(t *testing.T) {
+		if _, err := ParseTime("yesterday"); err == nil {
+			t.Error("Expected error for unsupported time")
+		}
+	})
+}
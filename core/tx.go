@@ -11,10 +11,41 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
 
+// ErrUnsupported is returned for the operation which is
+// not supported by the database, check it by errors.Is.
+var ErrUnsupported = errors.New("operation is not supported")
+
+// Capability is queried by Supports,
+// so that generic code can adapt to the database.
+type Capability int
+
+const (
+	// CapabilitySavePoint means TransactionContext supports SavePoint and RollbackTo.
+	CapabilitySavePoint Capability = iota
+	// CapabilityNested means PropagationNested is supported.
+	CapabilityNested
+)
+
+// CapabilityReporter is optionally implemented by
+// the TransactionManager to report its capabilities.
+type CapabilityReporter interface {
+	Supports(capability Capability) bool
+}
+
+// Supports reports whether tm supports the capability,
+// and reports false when tm is not a CapabilityReporter.
+func Supports(tm TransactionManager, capability Capability) bool {
+	if reporter, ok := tm.(CapabilityReporter); ok {
+		return reporter.Supports(capability)
+	}
+	return false
+}
+
 type RollbackError struct {
 	Err    error
 	Origin error
@@ -50,3 +81,16 @@ func RollbackFor(tc TransactionContext, origin error) error {
 	}
 	return &RollbackError{err, origin}
 }
+
+type transactionKey struct{}
+
+// TransactionKey is the context key to look up the current transaction,
+// the implementations of TransactionContext return themselves for it in Value.
+var TransactionKey any = transactionKey{}
+
+// GetTransaction returns the TransactionContext which ctx belongs to,
+// including the contexts derived from it, e.g., by context.WithValue.
+func GetTransaction(ctx context.Context) (TransactionContext, bool) {
+	tc, ok := ctx.Value(TransactionKey).(TransactionContext)
+	return tc, ok
+}
@@ -0,0 +1,49 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+// TxCallbacks holds the callbacks registered by
+// TransactionContext.OnCommit and TransactionContext.OnRollback,
+// and is embedded by the implementations of TransactionContext.
+type TxCallbacks struct {
+	commits   []func()
+	rollbacks []func()
+}
+
+func (c *TxCallbacks) OnCommit(callback func()) {
+	c.commits = append(c.commits, callback)
+}
+
+func (c *TxCallbacks) OnRollback(callback func()) {
+	c.rollbacks = append(c.rollbacks, callback)
+}
+
+// Complete runs the commit callbacks when the transaction
+// is committed without error, or the rollback callbacks otherwise.
+// The callbacks are cleared to run at most once.
+func (c *TxCallbacks) Complete(committed bool) {
+	callbacks := c.rollbacks
+	if committed {
+		callbacks = c.commits
+	}
+	c.commits, c.rollbacks = nil, nil
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// MergeInto moves the callbacks to the outer transaction,
+// which decides whether the changes are committed at last.
+func (c *TxCallbacks) MergeInto(outer *TxCallbacks) {
+	outer.commits = append(outer.commits, c.commits...)
+	outer.rollbacks = append(outer.rollbacks, c.rollbacks...)
+	c.commits, c.rollbacks = nil, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Propagation int
+
+const (
+	// PropagationRequired joins the current transaction, or starts a new one.
+	PropagationRequired Propagation = iota
+	// PropagationRequiresNew always starts a new transaction.
+	PropagationRequiresNew
+	// PropagationNested creates a save point in the current transaction, or starts a new one.
+	PropagationNested
+	// PropagationNotSupported runs without a transaction.
+	PropagationNotSupported
+)
+
+type TxOptions struct {
+	Propagation Propagation
+	Isolation   sql.IsolationLevel
+	ReadOnly    bool
+}
+
+type TxOption func(*TxOptions)
+
+func WithPropagation(propagation Propagation) TxOption {
+	return func(o *TxOptions) {
+		o.Propagation = propagation
+	}
+}
+
+func WithIsolation(isolation sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = isolation
+	}
+}
+
+func WithReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+func BuildTxOptions(opts ...TxOption) TxOptions {
+	options := TxOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// NonTransactionContext is the TransactionContext for PropagationNotSupported,
+// which suspends the current transaction by wrapping its parent context.
+type NonTransactionContext struct {
+	context.Context
+}
+
+func (c *NonTransactionContext) Commit() error {
+	return nil
+}
+
+func (c *NonTransactionContext) Rollback() error {
+	return nil
+}
+
+func (c *NonTransactionContext) Parent() context.Context {
+	return c.Context
+}
+
+// Value hides the suspended transaction from GetTransaction.
+func (c *NonTransactionContext) Value(key any) any {
+	if key == TransactionKey {
+		return nil
+	}
+	return c.Context.Value(key)
+}
+
+func (c *NonTransactionContext) SavePoint(string) error {
+	return ErrUnsupported
+}
+
+func (c *NonTransactionContext) RollbackTo(string) error {
+	return ErrUnsupported
+}
+
+// OnCommit runs the callback immediately without a transaction.
+func (c *NonTransactionContext) OnCommit(callback func()) {
+	callback()
+}
+
+func (c *NonTransactionContext) OnRollback(func()) {
+}
+
+// SuspendTransaction returns the closest ancestor of ctx
+// which is not a TransactionContext, and hides the transaction
+// from it when it is derived from a TransactionContext.
+func SuspendTransaction(ctx context.Context) context.Context {
+	for {
+		tc, ok := ctx.(TransactionContext)
+		if !ok {
+			break
+		}
+		ctx = tc.Parent()
+	}
+	if _, ok := GetTransaction(ctx); ok {
+		return &NonTransactionContext{Context: ctx}
+	}
+	return ctx
+}
@@ -0,0 +1,183 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var likeOps = map[string]bool{
+	"Like": true, "NotLike": true, "Contain": true, "NotContain": true,
+	"Start": true, "NotStart": true, "End": true, "NotEnd": true,
+}
+
+// FieldError reports an invalid field of the query object.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError aggregates the field errors found by ValidateQuery.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldError := range e.Errors {
+		messages[i] = fieldError.Error()
+	}
+	return "invalid query: " + strings.Join(messages, "; ")
+}
+
+// ValidateQuery validates the query object before building the conditions
+// by the tags of the query fields:
+//   - `required:"true"`: the field must be set;
+//   - `min:"1"` and `max:"100"`: bounds of the number or the length of the string;
+//   - `maxItems:"100"`: max length of the slice, default to Config.MaxItems;
+//   - `enum:"a,b"`: allowed values of the field or its elements.
+//
+// Blank values for the LIKE suffixes are rejected as well, and so are
+// the sort columns not in columns when columns is not empty.
+// It returns a *ValidationError holding the errors of all fields.
+func ValidateQuery(query any, columns []string) error {
+	v := &validator{}
+	v.validateStruct(reflect.ValueOf(query), "")
+	if q, ok := query.(Query); ok && len(columns) > 0 && q.GetSort() != nil {
+		for _, group := range SortRgx.FindAllStringSubmatch(*q.GetSort(), -1) {
+			if !containsFold(columns, group[1]) {
+				v.add("Sort", "has unknown column: "+group[1])
+			}
+		}
+	}
+	if len(v.errors) > 0 {
+		return &ValidationError{v.errors}
+	}
+	return nil
+}
+
+func containsFold(columns []string, column string) bool {
+	for _, col := range columns {
+		if strings.EqualFold(col, column) {
+			return true
+		}
+	}
+	return false
+}
+
+type validator struct {
+	errors []FieldError
+}
+
+func (v *validator) add(field string, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{field, fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validateStruct(rv reflect.Value, prefix string) {
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			v.validateStruct(rv.Field(i), prefix)
+		} else {
+			v.validateField(prefix+field.Name, field, rv.Field(i))
+		}
+	}
+}
+
+func (v *validator) validateField(name string, field reflect.StructField, value reflect.Value) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if field.Tag.Get("required") == "true" {
+				v.add(name, "is required")
+			}
+			return
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		v.checkItems(name, field, value)
+		for i := 0; i < value.Len(); i++ {
+			elem := reflect.Indirect(value.Index(i))
+			if elem.Kind() == reflect.Struct {
+				v.validateStruct(elem, name+"["+strconv.Itoa(i)+"].")
+			} else {
+				v.checkEnum(name, field, elem)
+			}
+		}
+	case reflect.Struct:
+		v.validateStruct(value, name+".")
+	case reflect.String:
+		s := value.String()
+		if match := SuffixRgx.FindStringSubmatch(field.Name); len(match) > 0 && likeOps[match[1]] && strings.TrimSpace(s) == "" {
+			v.add(name, "must not be blank")
+		}
+		v.checkBounds(name, field, float64(utf8.RuneCountInString(s)), "length ")
+		v.checkEnum(name, field, value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.checkBounds(name, field, float64(value.Int()), "")
+		v.checkEnum(name, field, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.checkBounds(name, field, float64(value.Uint()), "")
+		v.checkEnum(name, field, value)
+	case reflect.Float32, reflect.Float64:
+		v.checkBounds(name, field, value.Float(), "")
+	}
+}
+
+func (v *validator) checkBounds(name string, field reflect.StructField, n float64, subject string) {
+	if min, err := strconv.ParseFloat(field.Tag.Get("min"), 64); err == nil && n < min {
+		v.add(name, "%smust be at least %v", subject, min)
+	}
+	if max, err := strconv.ParseFloat(field.Tag.Get("max"), 64); err == nil && n > max {
+		v.add(name, "%smust be at most %v", subject, max)
+	}
+}
+
+func (v *validator) checkItems(name string, field reflect.StructField, value reflect.Value) {
+	maxItems := Config.MaxItems
+	if tag, ok := field.Tag.Lookup("maxItems"); ok {
+		maxItems, _ = strconv.Atoi(tag)
+	}
+	if maxItems > 0 && value.Len() > maxItems {
+		v.add(name, "must have at most %d items", maxItems)
+	}
+}
+
+func (v *validator) checkEnum(name string, field reflect.StructField, value reflect.Value) {
+	tag, ok := field.Tag.Lookup("enum")
+	if !ok {
+		return
+	}
+	s := fmt.Sprint(value.Interface())
+	for _, option := range strings.Split(tag, ",") {
+		if s == option {
+			return
+		}
+	}
+	v.add(name, "must be one of [%s]", tag)
+}
@@ -0,0 +1,68 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package core
+
+import (
+	"testing"
+)
+
+type validQuery struct {
+	PageQuery
+	Status      *string `required:"true" enum:"active,closed"`
+	ScoreGe     *int    `min:"0" max:"100"`
+	NameContain *string `max:"5"`
+	IdIn        *[]int
+	TagIn       *[]string `maxItems:"2" enum:"a,b,c"`
+	ScoreOr     *validQuery
+}
+
+func TestValidateQuery(t *testing.T) {
+	columns := []string{"id", "score", "status"}
+	tests := []struct {
+		name   string
+		query  validQuery
+		expect string
+	}{
+		{"Valid query", validQuery{PageQuery: PageQuery{PageSize: P(10), Sort: P("id,desc;SCORE")}, Status: P("active"), ScoreGe: P(60), TagIn: &[]string{"a", "c"}}, ""},
+		{"Required and enum", validQuery{Status: P("open")}, "invalid query: Status must be one of [active,closed]"},
+		{"Missing required", validQuery{}, "invalid query: Status is required"},
+		{"Negative page size", validQuery{PageQuery: PageQuery{PageNumber: P(-1), PageSize: P(-5)}, Status: P("active")},
+			"invalid query: PageNumber must be at least 0; PageSize must be at least 0"},
+		{"Unknown sort column", validQuery{PageQuery: PageQuery{Sort: P("name,asc;id")}, Status: P("active")}, "invalid query: Sort has unknown column: name"},
+		{"Blank like value and length", validQuery{Status: P("active"), NameContain: P(" "), ScoreOr: &validQuery{Status: P("closed"), NameContain: P("abcdef")}},
+			"invalid query: NameContain must not be blank; ScoreOr.NameContain length must be at most 5"},
+		{"Out of range", validQuery{Status: P("active"), ScoreGe: P(101)}, "invalid query: ScoreGe must be at most 100"},
+		{"Too many items", validQuery{Status: P("active"), IdIn: P(make([]int, 1001)), TagIn: &[]string{"a", "b", "d"}},
+			"invalid query: TagIn must have at most 2 items; TagIn must be one of [a,b,c]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ""
+			if err := ValidateQuery(tt.query, columns); err != nil {
+				actual = err.Error()
+			}
+			if actual != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, actual)
+			}
+		})
+	}
+
+	t.Run("Limit items by Config.MaxItems", func(t *testing.T) {
+		Config.MaxItems = 1000
+		defer func() { Config.MaxItems = 0 }()
+
+		err := ValidateQuery(validQuery{Status: P("active"), IdIn: P(make([]int, 1001))}, columns)
+		expect := "invalid query: IdIn must have at most 1000 items"
+		if err == nil || err.Error() != expect {
+			t.Errorf("\nExpected: %s\nBut got : %v", expect, err)
+		}
+	})
+}
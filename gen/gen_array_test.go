@@ -0,0 +1,204 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package gen
+
+import (
+	"testing"
+)
+
+const articleSrc = `package main
+
+type ArticleQuery struct {
+	PageQuery
+	TagsContains *[]string
+	TagsOverlap  *[]string
+	TagsAnyEq    *string
+}
+`
+
+func TestArrayOperators(t *testing.T) {
+	RegisterArrayOperators()
+	input := writeSource(t, articleSrc)
+
+	tests := []struct {
+		name      string
+		generator Generator
+		expect    string
+	}{
+		{"sql", NewSqlGenerator(), `package main
+
+import . "github.com/doytowin/goooqo/rdb"
+import "strings"
+
+func (q ArticleQuery) BuildConditions() ([]string, []any) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 4)
+	if q.TagsContains != nil {
+		conditions = append(conditions, "tags @> ?")
+		args = append(args, Array(*q.TagsContains))
+	}
+	if q.TagsOverlap != nil {
+		conditions = append(conditions, "tags && ?")
+		args = append(args, Array(*q.TagsOverlap))
+	}
+	if q.TagsAnyEq != nil {
+		conditions = append(conditions, "tags @> ?")
+		args = append(args, Array(*q.TagsAnyEq))
+	}
+	return conditions, args
+}
+`},
+		{"mongo", NewMongoGenerator(), `package main
+
+import . "go.mongodb.org/mongo-driver/bson/primitive"
+
+func (q ArticleQuery) BuildFilter() A {
+	d := make(A, 0, 4)
+	if q.TagsContains != nil {
+		d = append(d, D{{"tags", D{{"$all", q.TagsContains}}}})
+	}
+	if q.TagsOverlap != nil {
+		d = append(d, D{{"tags", D{{"$in", q.TagsOverlap}}}})
+	}
+	if q.TagsAnyEq != nil {
+		d = append(d, D{{"tags", D{{"$eq", q.TagsAnyEq}}}})
+	}
+	return d
+}
+`},
+	}
+	for _, tt := range tests {
+		t.Run("Generate for "+tt.name, func(t *testing.T) {
+			code := GenerateCode(input, tt.generator)
+			if code != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, code)
+			}
+		})
+	}
+}
+
+const notSrc = `package main
+
+type UserQuery struct {
+	PageQuery
+	ScoreLt *int
+	UserNot *UserCond
+}
+
+type UserCond struct {
+	Name    *string
+	ScoreGt *int
+}
+`
+
+func TestNotGroup(t *testing.T) {
+	input := writeSource(t, notSrc)
+
+	tests := []struct {
+		name      string
+		generator Generator
+		expect    string
+	}{
+		{"sql", NewSqlGenerator(), `package main
+
+import . "github.com/doytowin/goooqo/rdb"
+import "strings"
+
+func (q UserQuery) BuildConditions() ([]string, []any) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 4)
+	if q.ScoreLt != nil {
+		conditions = append(conditions, "score < ?")
+		args = append(args, *q.ScoreLt)
+	}
+	if q.UserNot != nil {
+		if cond, args0 := BuildConditions(q.UserNot, "NOT (", " AND ", ")"); cond != "" {
+			conditions = append(conditions, cond)
+			args = append(args, args0...)
+		}
+	}
+	return conditions, args
+}
+`},
+		{"mongo", NewMongoGenerator(), `package main
+
+import . "go.mongodb.org/mongo-driver/bson/primitive"
+
+func (q UserQuery) BuildFilter() A {
+	d := make(A, 0, 4)
+	if q.ScoreLt != nil {
+		d = append(d, D{{"score", D{{"$lt", q.ScoreLt}}}})
+	}
+	if q.UserNot != nil {
+		if not := q.UserNot.BuildFilter(); len(not) > 0 {
+			d = append(d, D{{"$nor", A{D{{"$and", not}}}}})
+		}
+	}
+	return d
+}
+
+func (q UserCond) BuildFilter() A {
+	d := make(A, 0, 4)
+	if q.Name != nil {
+		d = append(d, D{{"name", D{{"$eq", q.Name}}}})
+	}
+	if q.ScoreGt != nil {
+		d = append(d, D{{"score", D{{"$gt", q.ScoreGt}}}})
+	}
+	return d
+}
+`},
+	}
+	for _, tt := range tests {
+		t.Run("Generate for "+tt.name, func(t *testing.T) {
+			code := GenerateCode(input, tt.generator)
+			if code != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, code)
+			}
+		})
+	}
+}
+
+const existsSrc = "package main\n\n" +
+	"type UserQuery struct {\n" +
+	"\tPageQuery\n" +
+	"\tRoleExists    *RoleQuery `exists:\"user,role\"`\n" +
+	"\tRoleNotExists *RoleQuery `exists:\"user,role\" foreignField:\"createUserId\"`\n" +
+	"}\n"
+
+func TestExistsTag(t *testing.T) {
+	input := writeSource(t, existsSrc)
+	expect := `package main
+
+import . "github.com/doytowin/goooqo/rdb"
+import "strings"
+
+func (q UserQuery) BuildConditions() ([]string, []any) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 4)
+	if q.RoleExists != nil {
+		and, args1 := BuildConditions(q.RoleExists, " AND ", " AND ", "")
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id AND EXISTS (SELECT 1 FROM t_role WHERE t_role.id = a_user_and_role.role_id"+and+"))")
+		args = append(args, args1...)
+	}
+	if q.RoleNotExists != nil {
+		and, args1 := BuildConditions(q.RoleNotExists, " AND ", " AND ", "")
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM t_role WHERE t_role.create_user_id = t_user.id"+and+")")
+		args = append(args, args1...)
+	}
+	return conditions, args
+}
+`
+	code := GenerateCode(input, NewSqlGenerator())
+	if code != expect {
+		t.Errorf("\nExpected: %s\nBut got : %s", expect, code)
+	}
+}
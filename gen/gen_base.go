@@ -15,15 +15,29 @@ import (
 	"fmt"
 	"github.com/doytowin/goooqo/core"
 	"go/ast"
+	"path"
 	"strings"
 )
 
 var opMap = make(map[string]map[string]operator)
 
+// RegisterArrayOperators registers the suffixes `Contains`, `Overlap` and `AnyEq`
+// for the array columns to both generators, the same as rdb.RegisterArrayOperators.
+func RegisterArrayOperators() {
+	RegisterSqlOperator("Contains", "@>", "")
+	RegisterSqlOperator("Overlap", "&&", "")
+	RegisterSqlOperator("AnyEq", "@>", "")
+	RegisterMongoOperator("Contains", "$all", "")
+	RegisterMongoOperator("Overlap", "$in", "")
+	RegisterMongoOperator("AnyEq", "$eq", "")
+}
+
 type operator struct {
 	name   string
 	sign   string
 	format string
+	// arg is the expression of the arg for the field, empty for the field value.
+	arg string
 }
 
 type Generator interface {
@@ -37,15 +51,19 @@ type Generator interface {
 
 type generator struct {
 	*bytes.Buffer
-	key        string
-	imports    []string
-	bodyFormat string
-	ifFormat   string
-	intent     string
-	replaceIns func(string) string
-	structList []*ast.TypeSpec
-	structIdx  int
-	prefix     []string
+	key     string
+	imports []string
+	// importsIfUsed holds the imports added only when
+	// their packages are referred to in the generated code.
+	importsIfUsed []string
+	importsEnd    int
+	bodyFormat    string
+	ifFormat      string
+	intent        string
+	replaceIns    func(string) string
+	structList    []*ast.TypeSpec
+	structIdx     int
+	prefix        []string
 }
 
 func newGenerator(key string, imports []string, bodyFormat string) *generator {
@@ -74,6 +92,19 @@ func (g *generator) appendImports() {
 		g.WriteString("import " + s)
 		g.WriteString(NewLine)
 	}
+	g.importsEnd = g.Len()
+}
+
+// String inserts the imports of importsIfUsed referred to in the code.
+func (g *generator) String() string {
+	code := g.Buffer.String()
+	head, body := code[:g.importsEnd], code[g.importsEnd:]
+	for _, s := range g.importsIfUsed {
+		if strings.Contains(body, path.Base(strings.Trim(s, `"`))+".") {
+			head += "import " + s + NewLine
+		}
+	}
+	return head + body
 }
 
 func (g *generator) appendIfEnd() {
@@ -107,10 +138,12 @@ func (g *generator) writeInstruction(ins string, args ...any) {
 
 func (g *generator) suffixMatch(fieldName string) (string, operator) {
 	if match := core.SuffixRgx.FindStringSubmatch(fieldName); len(match) > 0 {
-		op := opMap[g.key][match[1]]
-		column := strings.TrimSuffix(fieldName, match[1])
-		column = core.ConvertToColumnCase(column)
-		return column, op
+		// the suffix may be registered for other generators only
+		if op, ok := opMap[g.key][match[1]+match[2]]; ok {
+			column := strings.TrimSuffix(fieldName, match[0])
+			column = core.ConvertToColumnCase(column)
+			return column, op
+		}
 	}
 	return core.ConvertToColumnCase(fieldName), opMap[g.key]["Eq"]
 }
@@ -11,6 +11,9 @@
 package gen
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -22,6 +25,7 @@ func TestExampleCommentMap(t *testing.T) {
 		{input: "../main/inventory.go", output: "../main/inventory_query_builder.go", expect: `package main
 
 import . "go.mongodb.org/mongo-driver/bson/primitive"
+import "regexp"
 
 func (q InventoryQuery) BuildFilter() A {
 	d := make(A, 0, 4)
@@ -52,6 +56,14 @@ func (q InventoryQuery) BuildFilter() A {
 	if q.QtyLe != nil {
 		d = append(d, D{{"qty", D{{"$lte", q.QtyLe}}}})
 	}
+	if q.QtyBetween != nil {
+		if q.QtyBetween.From != nil {
+			d = append(d, D{{"qty", D{{"$gte", q.QtyBetween.From}}}})
+		}
+		if q.QtyBetween.To != nil {
+			d = append(d, D{{"qty", D{{"$lte", q.QtyBetween.To}}}})
+		}
+	}
 	if q.Size != nil {
 		d = append(d, q.Size.BuildFilter()...)
 	}
@@ -63,22 +75,28 @@ func (q InventoryQuery) BuildFilter() A {
 		}
 	}
 	if q.ItemContain != nil && *q.ItemContain != "" {
-		d = append(d, D{{"item", D{{"$regex", q.ItemContain}}}})
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemContain)}}}})
 	}
 	if q.ItemNotContain != nil && *q.ItemNotContain != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", q.ItemNotContain}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotContain)}}}}}})
 	}
 	if q.ItemStart != nil && *q.ItemStart != "" {
-		d = append(d, D{{"item", D{{"$regex", "^" + *q.ItemStart}}}})
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemStart)}}}})
 	}
 	if q.ItemNotStart != nil && *q.ItemNotStart != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", "^" + *q.ItemNotStart}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemNotStart)}}}}}})
 	}
 	if q.ItemEnd != nil && *q.ItemEnd != "" {
-		d = append(d, D{{"item", D{{"$regex", *q.ItemEnd + "$"}}}})
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemEnd) + "$"}}}})
 	}
 	if q.ItemNotEnd != nil && *q.ItemNotEnd != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", *q.ItemNotEnd + "$"}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotEnd) + "$"}}}}}})
+	}
+	if q.ItemEqIgnoreCase != nil {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemEqIgnoreCase) + "$"}, {"$options", "i"}}}})
+	}
+	if q.ItemStartIgnoreCase != nil && *q.ItemStartIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemStartIgnoreCase)}, {"$options", "i"}}}})
 	}
 	if q.CustomFilter != nil {
 		d = append(d, *q.CustomFilter)
@@ -180,6 +198,18 @@ func (q UserQuery) BuildConditions() ([]string, []any) {
 		conditions = append(conditions, "score < ?")
 		args = append(args, *q.ScoreLt)
 	}
+	if q.ScoreBetween != nil {
+		if q.ScoreBetween.From != nil && q.ScoreBetween.To != nil {
+			conditions = append(conditions, "score BETWEEN ? AND ?")
+			args = append(args, *q.ScoreBetween.From, *q.ScoreBetween.To)
+		} else if q.ScoreBetween.From != nil {
+			conditions = append(conditions, "score >= ?")
+			args = append(args, *q.ScoreBetween.From)
+		} else if q.ScoreBetween.To != nil {
+			conditions = append(conditions, "score <= ?")
+			args = append(args, *q.ScoreBetween.To)
+		}
+	}
 	if q.MemoNull != nil {
 		if *q.MemoNull {
 			conditions = append(conditions, "memo IS NULL")
@@ -187,9 +217,14 @@ func (q UserQuery) BuildConditions() ([]string, []any) {
 			conditions = append(conditions, "memo IS NOT NULL")
 		}
 	}
-	if q.MemoLike != nil {
-		conditions = append(conditions, "memo LIKE ?")
-		args = append(args, *q.MemoLike)
+	if q.MemoLike != nil && strings.TrimSpace(*q.MemoLike) != "" {
+		arg := *q.MemoLike
+		conditions = append(conditions, "memo LIKE ?"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+	if q.MemoEqIgnoreCase != nil {
+		conditions = append(conditions, "LOWER(memo) = LOWER(?)")
+		args = append(args, *q.MemoEqIgnoreCase)
 	}
 	if q.Deleted != nil {
 		conditions = append(conditions, "deleted = ?")
@@ -229,3 +264,180 @@ func (q UserQuery) BuildConditions() ([]string, []any) {
 		})
 	}
 }
+
+func writeSource(t *testing.T, src string) string {
+	input := filepath.Join(t.TempDir(), "query.go")
+	if err := os.WriteFile(input, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+const memoSrc = `package main
+
+type MemoQuery struct {
+	PageQuery
+	MemoEqIgnoreCase         *string
+	MemoNeIgnoreCase         *string
+	MemoInIgnoreCase         *[]string
+	MemoNotInIgnoreCase      *[]string
+	MemoLikeIgnoreCase       *string
+	MemoNotLikeIgnoreCase    *string
+	MemoContainIgnoreCase    *string
+	MemoNotContainIgnoreCase *string
+	MemoStartIgnoreCase      *string
+	MemoNotStartIgnoreCase   *string
+	MemoEndIgnoreCase        *string
+	MemoNotEndIgnoreCase     *string
+}
+`
+
+func TestSqlIgnoreCaseOperators(t *testing.T) {
+	input := writeSource(t, memoSrc)
+	tests := []struct {
+		name   string
+		expect string
+	}{
+		{"MemoEqIgnoreCase", `	if q.MemoEqIgnoreCase != nil {
+		conditions = append(conditions, "LOWER(memo) = LOWER(?)")
+		args = append(args, *q.MemoEqIgnoreCase)
+	}
+`},
+		{"MemoNeIgnoreCase", `	if q.MemoNeIgnoreCase != nil {
+		conditions = append(conditions, "LOWER(memo) <> LOWER(?)")
+		args = append(args, *q.MemoNeIgnoreCase)
+	}
+`},
+		{"MemoInIgnoreCase", `	if q.MemoInIgnoreCase != nil {
+		phs := make([]string, 0, len(*q.MemoInIgnoreCase))
+		for _, arg := range *q.MemoInIgnoreCase {
+			args = append(args, arg)
+			phs = append(phs, "LOWER(?)")
+		}
+		conditions = append(conditions, "LOWER(memo) IN ("+strings.Join(phs, ", ")+")")
+	}
+`},
+		{"MemoNotInIgnoreCase", `	if q.MemoNotInIgnoreCase != nil {
+		phs := make([]string, 0, len(*q.MemoNotInIgnoreCase))
+		for _, arg := range *q.MemoNotInIgnoreCase {
+			args = append(args, arg)
+			phs = append(phs, "LOWER(?)")
+		}
+		conditions = append(conditions, "LOWER(memo) NOT IN ("+strings.Join(phs, ", ")+")")
+	}
+`},
+		{"MemoLikeIgnoreCase", `	if q.MemoLikeIgnoreCase != nil && strings.TrimSpace(*q.MemoLikeIgnoreCase) != "" {
+		arg := *q.MemoLikeIgnoreCase
+		conditions = append(conditions, "LOWER(memo) LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoNotLikeIgnoreCase", `	if q.MemoNotLikeIgnoreCase != nil && strings.TrimSpace(*q.MemoNotLikeIgnoreCase) != "" {
+		arg := *q.MemoNotLikeIgnoreCase
+		conditions = append(conditions, "LOWER(memo) NOT LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoContainIgnoreCase", `	if q.MemoContainIgnoreCase != nil && strings.TrimSpace(*q.MemoContainIgnoreCase) != "" {
+		arg := "%" + EscapeLike(*q.MemoContainIgnoreCase) + "%"
+		conditions = append(conditions, "LOWER(memo) LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoNotContainIgnoreCase", `	if q.MemoNotContainIgnoreCase != nil && strings.TrimSpace(*q.MemoNotContainIgnoreCase) != "" {
+		arg := "%" + EscapeLike(*q.MemoNotContainIgnoreCase) + "%"
+		conditions = append(conditions, "LOWER(memo) NOT LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoStartIgnoreCase", `	if q.MemoStartIgnoreCase != nil && strings.TrimSpace(*q.MemoStartIgnoreCase) != "" {
+		arg := EscapeLike(*q.MemoStartIgnoreCase) + "%"
+		conditions = append(conditions, "LOWER(memo) LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoNotStartIgnoreCase", `	if q.MemoNotStartIgnoreCase != nil && strings.TrimSpace(*q.MemoNotStartIgnoreCase) != "" {
+		arg := EscapeLike(*q.MemoNotStartIgnoreCase) + "%"
+		conditions = append(conditions, "LOWER(memo) NOT LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoEndIgnoreCase", `	if q.MemoEndIgnoreCase != nil && strings.TrimSpace(*q.MemoEndIgnoreCase) != "" {
+		arg := "%" + EscapeLike(*q.MemoEndIgnoreCase)
+		conditions = append(conditions, "LOWER(memo) LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+		{"MemoNotEndIgnoreCase", `	if q.MemoNotEndIgnoreCase != nil && strings.TrimSpace(*q.MemoNotEndIgnoreCase) != "" {
+		arg := "%" + EscapeLike(*q.MemoNotEndIgnoreCase)
+		conditions = append(conditions, "LOWER(memo) NOT LIKE LOWER(?)"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+`},
+	}
+	code := GenerateCode(input, NewSqlGenerator())
+	for _, tt := range tests {
+		t.Run("Generate for "+tt.name, func(t *testing.T) {
+			if !strings.Contains(code, tt.expect) {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, code)
+			}
+		})
+	}
+}
+
+const itemSrc = `package main
+
+type ItemQuery struct {
+	PageQuery
+	ItemContain              *string
+	ItemEqIgnoreCase         *string
+	ItemContainIgnoreCase    *string
+	ItemNotContainIgnoreCase *string
+	ItemStartIgnoreCase      *string
+	ItemNotStartIgnoreCase   *string
+	ItemEndIgnoreCase        *string
+	ItemNotEndIgnoreCase     *string
+}
+`
+
+func TestMongoQuoteRegex(t *testing.T) {
+	input := writeSource(t, itemSrc)
+	expect := `package main
+
+import . "go.mongodb.org/mongo-driver/bson/primitive"
+import "regexp"
+
+func (q ItemQuery) BuildFilter() A {
+	d := make(A, 0, 4)
+	if q.ItemContain != nil && *q.ItemContain != "" {
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemContain)}}}})
+	}
+	if q.ItemEqIgnoreCase != nil {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemEqIgnoreCase) + "$"}, {"$options", "i"}}}})
+	}
+	if q.ItemContainIgnoreCase != nil && *q.ItemContainIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemContainIgnoreCase)}, {"$options", "i"}}}})
+	}
+	if q.ItemNotContainIgnoreCase != nil && *q.ItemNotContainIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotContainIgnoreCase)}, {"$options", "i"}}}}}})
+	}
+	if q.ItemStartIgnoreCase != nil && *q.ItemStartIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemStartIgnoreCase)}, {"$options", "i"}}}})
+	}
+	if q.ItemNotStartIgnoreCase != nil && *q.ItemNotStartIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemNotStartIgnoreCase)}, {"$options", "i"}}}}}})
+	}
+	if q.ItemEndIgnoreCase != nil && *q.ItemEndIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemEndIgnoreCase) + "$"}, {"$options", "i"}}}})
+	}
+	if q.ItemNotEndIgnoreCase != nil && *q.ItemNotEndIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotEndIgnoreCase) + "$"}, {"$options", "i"}}}}}})
+	}
+	return d
+}
+`
+	code := GenerateCode(input, NewMongoGenerator())
+	if code != expect {
+		t.Errorf("\nExpected: %s\nBut got : %s", expect, code)
+	}
+}
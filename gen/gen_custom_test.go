@@ -0,0 +1,85 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const placeSrc = `package main
+
+type PlaceQuery struct {
+	PageQuery
+	ScoreMod     *int
+	LocationNear *Point
+}
+`
+
+func TestRegisterOperator(t *testing.T) {
+	RegisterSqlOperator("Mod", "%", "conditions = append(conditions, \"%s %s ? = 0\")")
+	RegisterMongoOperator("Near", "$near", "")
+
+	input := filepath.Join(t.TempDir(), "place.go")
+	if err := os.WriteFile(input, []byte(placeSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		generator Generator
+		expect    string
+	}{
+		{"sql", NewSqlGenerator(), `package main
+
+import . "github.com/doytowin/goooqo/rdb"
+import "strings"
+
+func (q PlaceQuery) BuildConditions() ([]string, []any) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 4)
+	if q.ScoreMod != nil {
+		conditions = append(conditions, "score % ? = 0")
+		args = append(args, *q.ScoreMod)
+	}
+	if q.LocationNear != nil {
+		conditions = append(conditions, "location_near = ?")
+		args = append(args, *q.LocationNear)
+	}
+	return conditions, args
+}
+`},
+		{"mongo", NewMongoGenerator(), `package main
+
+import . "go.mongodb.org/mongo-driver/bson/primitive"
+
+func (q PlaceQuery) BuildFilter() A {
+	d := make(A, 0, 4)
+	if q.ScoreMod != nil {
+		d = append(d, D{{"score_mod", D{{"$eq", q.ScoreMod}}}})
+	}
+	if q.LocationNear != nil {
+		d = append(d, D{{"location", D{{"$near", q.LocationNear}}}})
+	}
+	return d
+}
+`},
+	}
+	for _, tt := range tests {
+		t.Run("Generate for "+tt.name, func(t *testing.T) {
+			code := GenerateCode(input, tt.generator)
+			if code != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, code)
+			}
+		})
+	}
+}
@@ -23,14 +23,24 @@ type MongoGenerator struct {
 }
 
 func NewMongoGenerator() *MongoGenerator {
-	return &MongoGenerator{newGenerator("mongo",
+	g := newGenerator("mongo",
 		[]string{`. "go.mongodb.org/mongo-driver/bson/primitive"`},
 		"d = append(d, D{{\"%s\", D{{\"%s\", q.%s}}}})",
-	)}
+	)
+	g.importsIfUsed = []string{`"regexp"`}
+	return &MongoGenerator{g}
 }
 
 const regexSign = "$regex"
 
+// RegisterMongoOperator registers a custom suffix operator for MongoGenerator,
+// the format is filled with the column, the sign and the field name,
+// and an empty format defaults to `D{{column, D{{sign, value}}}}`.
+func RegisterMongoOperator(name, sign, format string) {
+	opMap["mongo"][name] = operator{name: name, sign: sign, format: format}
+	core.RegisterSuffix(name)
+}
+
 func init() {
 	mongoOpMap := make(map[string]operator)
 	mongoOpMap["Eq"] = operator{name: "Eq", sign: "$eq"}
@@ -41,36 +51,48 @@ func init() {
 	mongoOpMap["Le"] = operator{name: "Le", sign: "$lte"}
 	mongoOpMap["In"] = operator{name: "In", sign: "$in"}
 	mongoOpMap["NotIn"] = operator{name: "NotIn", sign: "$nin"}
+	mongoOpMap["Between"] = operator{name: "Between", sign: "$gte"}
 	mongoOpMap["Null"] = operator{name: "Null", sign: "$type"}
 	mongoOpMap["Contain"] = operator{
 		name:   "Contain",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"%s\", q.%s}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"%s\", regexp.QuoteMeta(*q.%s)}}}})",
 	}
 	mongoOpMap["NotContain"] = operator{
 		name:   "NotContain",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", q.%s}}}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", regexp.QuoteMeta(*q.%s)}}}}}})",
 	}
 	mongoOpMap["Start"] = operator{
 		name:   "Start",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"%s\", \"^\" + *q.%s}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"%s\", \"^\" + regexp.QuoteMeta(*q.%s)}}}})",
 	}
 	mongoOpMap["NotStart"] = operator{
 		name:   "NotStart",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", \"^\" + *q.%s}}}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", \"^\" + regexp.QuoteMeta(*q.%s)}}}}}})",
 	}
 	mongoOpMap["End"] = operator{
 		name:   "End",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"%s\", *q.%s + \"$\"}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"%s\", regexp.QuoteMeta(*q.%s) + \"$\"}}}})",
 	}
 	mongoOpMap["NotEnd"] = operator{
 		name:   "NotEnd",
 		sign:   regexSign,
-		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", *q.%s + \"$\"}}}}}})",
+		format: "d = append(d, D{{\"%s\", D{{\"$not\", D{{\"%s\", regexp.QuoteMeta(*q.%s) + \"$\"}}}}}})",
+	}
+	for _, name := range []string{"Contain", "NotContain", "Start", "NotStart", "End", "NotEnd"} {
+		op := mongoOpMap[name]
+		op.name = name + core.IgnoreCase
+		op.format = strings.Replace(op.format, "}}", "}, {\"$options\", \"i\"}}", 1)
+		mongoOpMap[op.name] = op
+	}
+	mongoOpMap["EqIgnoreCase"] = operator{
+		name:   "EqIgnoreCase",
+		sign:   regexSign,
+		format: "d = append(d, D{{\"%s\", D{{\"%s\", \"^\" + regexp.QuoteMeta(*q.%s) + \"$\"}, {\"$options\", \"i\"}}}})",
 	}
 	opMap["mongo"] = mongoOpMap
 }
@@ -147,8 +169,21 @@ func (g *MongoGenerator) appendCondition(field *ast.Field, path []string, fieldN
 		g.writeInstruction("\t} else {")
 		g.writeInstruction(g.replaceIns("\t\td = append(d, D{{\"%s\", D{{\"$not\", D{{\"$type\", 10}}}}}})"), column)
 		g.writeInstruction("\t}")
+	} else if op.name == "Between" {
+		g.appendIfStartNil(structName)
+		g.writeInstruction("\tif q.%s.From != nil {", structName)
+		g.writeInstruction(g.replaceIns("\t\td = append(d, D{{\"%s\", D{{\"$gte\", q.%s.From}}}})"), column, structName)
+		g.writeInstruction("\t}")
+		g.writeInstruction("\tif q.%s.To != nil {", structName)
+		g.writeInstruction(g.replaceIns("\t\td = append(d, D{{\"%s\", D{{\"$lte\", q.%s.To}}}})"), column, structName)
+		g.writeInstruction("\t}")
 	} else if op.sign == regexSign {
-		g.writeInstruction("if q.%s != nil && *q.%s != \"\" {", structName, structName)
+		if op.name == "EqIgnoreCase" {
+			// matches the empty string as Eq does
+			g.appendIfStartNil(structName)
+		} else {
+			g.writeInstruction("if q.%s != nil && *q.%s != \"\" {", structName, structName)
+		}
 		g.appendIfBody(op.format, column, op.sign, structName)
 	} else if fieldName == "Search" {
 		g.appendIfStartNil(structName)
@@ -166,7 +201,12 @@ func (g *MongoGenerator) appendCondition(field *ast.Field, path []string, fieldN
 
 func (g *MongoGenerator) appendSubStruct(ts *ast.TypeSpec, structName string, fieldName string, path []string, column string) {
 	g.appendIfStartNil(structName)
-	if strings.HasSuffix(fieldName, "Or") {
+	if strings.HasSuffix(fieldName, "Not") {
+		g.addStruct(g.prefix[g.structIdx-1], ts)
+		g.writeInstruction("\tif not := q.%s.BuildFilter(); len(not) > 0 {", structName)
+		g.writeInstruction(g.replaceIns("\t\td = append(d, D{{\"$nor\", A{D{{\"$and\", not}}}}})"))
+		g.writeInstruction("\t}")
+	} else if strings.HasSuffix(fieldName, "Or") {
 		if lenP := len(path); lenP > 0 && strings.HasSuffix(path[lenP-1], "Or") {
 			g.appendOrOrBody(path, fieldName)
 		} else {
@@ -11,6 +11,7 @@
 package gen
 
 import (
+	"github.com/doytowin/goooqo/core"
 	"github.com/doytowin/goooqo/rdb"
 	log "github.com/sirupsen/logrus"
 	"go/ast"
@@ -34,12 +35,41 @@ func init() {
 	sqlOpMap["Le"] = operator{name: "Le", sign: "<="}
 	sqlOpMap["In"] = operator{name: "In", sign: "IN", format: "conditions = append(conditions, \"%s %s (\"+strings.Join(phs, \", \")+\")\")"}
 	sqlOpMap["NotIn"] = operator{name: "NotIn", sign: "NOT IN", format: "conditions = append(conditions, \"%s %s (\"+strings.Join(phs, \", \")+\")\")"}
+	sqlOpMap["Between"] = operator{name: "Between", sign: "BETWEEN", format: "conditions = append(conditions, \"%s %s ? AND ?\")"}
 	sqlOpMap["Null"] = operator{name: "Null", sign: "IS NULL", format: "conditions = append(conditions, \"%s %s\")"}
-	sqlOpMap["Like"] = operator{name: "Like", sign: "LIKE", format: format}
-	sqlOpMap["Contain"] = operator{name: "Contain", sign: "LIKE", format: format}
+	likeArgs := map[string]string{
+		"Like":    "*q.%s",
+		"Contain": "\"%%\" + EscapeLike(*q.%s) + \"%%\"",
+		"Start":   "EscapeLike(*q.%s) + \"%%\"",
+		"End":     "\"%%\" + EscapeLike(*q.%s)",
+	}
+	for name, arg := range likeArgs {
+		sqlOpMap[name] = operator{name: name, sign: "LIKE", format: likeFormat, arg: arg}
+		sqlOpMap["Not"+name] = operator{name: "Not" + name, sign: "NOT LIKE", format: likeFormat, arg: arg}
+	}
+	for _, name := range []string{"Eq", "Ne", "In", "NotIn", "Like", "NotLike", "Contain", "NotContain", "Start", "NotStart", "End", "NotEnd"} {
+		op := sqlOpMap[name]
+		op.name = name + core.IgnoreCase
+		if op.format == "" {
+			op.format = format
+		}
+		op.format = strings.Replace(op.format, "\"%s %s ?", "\"LOWER(%s) %s LOWER(?)", 1)
+		op.format = strings.Replace(op.format, "\"%s %s (", "\"LOWER(%s) %s (", 1)
+		sqlOpMap[op.name] = op
+	}
 	opMap["sql"] = sqlOpMap
 }
 
+const likeFormat = "conditions = append(conditions, \"%s %s ?\"+EscapeClause(arg))"
+
+// RegisterSqlOperator registers a custom suffix operator for SqlGenerator,
+// the format is filled with the column and the sign followed by the field value as the arg,
+// and an empty format defaults to `column sign ?`.
+func RegisterSqlOperator(name, sign, format string) {
+	opMap["sql"][name] = operator{name: name, sign: sign, format: format}
+	core.RegisterSuffix(name)
+}
+
 func NewSqlGenerator() *SqlGenerator {
 	return &SqlGenerator{newGenerator("sql",
 		[]string{`. "github.com/doytowin/goooqo/rdb"`, `"strings"`}, format,
@@ -80,7 +110,12 @@ func (g *SqlGenerator) appendCondition(field *ast.Field, fieldName string) {
 	if field.Tag != nil {
 		g.appendIfStartNil(fieldName)
 		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
-		if subqueryTag, ok := tag.Lookup("subquery"); ok {
+		if _, ok := tag.Lookup("exists"); ok {
+			fpExists := rdb.BuildByExistsTag(tag, fieldName)
+			g.appendIfBody("and, args1 := BuildConditions(q.%s, \" AND \", \" AND \", \"\")", fieldName)
+			g.appendIfBody("conditions = append(conditions, \"%s\"+and+\"%s\")", fpExists.Subquery(), fpExists.Closing())
+			g.appendIfBody("args = append(args, args1...)")
+		} else if subqueryTag, ok := tag.Lookup("subquery"); ok {
 			fpSubquery := rdb.BuildBySubqueryTag(subqueryTag, fieldName)
 			subSelect := fpSubquery.Subquery()
 			g.genSubquery(fieldName, subSelect)
@@ -105,19 +140,52 @@ func (g *SqlGenerator) appendCondition(field *ast.Field, fieldName string) {
 		g.appendIfBody(op.format, column, "IS NOT NULL")
 		g.appendIfEnd()
 		g.restoreIntent(intent)
+	} else if op.name == "Between" {
+		g.appendIfStartNil(fieldName)
+		intent := g.incIntent()
+		g.writeInstruction("if q.%s.From != nil && q.%s.To != nil {", fieldName, fieldName)
+		g.appendIfBody(op.format, column, op.sign)
+		g.appendIfBody("args = append(args, *q.%s.From, *q.%s.To)", fieldName, fieldName)
+		g.writeInstruction("} else if q.%s.From != nil {", fieldName)
+		g.appendIfBody(format, column, ">=")
+		g.appendIfBody("args = append(args, *q.%s.From)", fieldName)
+		g.writeInstruction("} else if q.%s.To != nil {", fieldName)
+		g.appendIfBody(format, column, "<=")
+		g.appendIfBody("args = append(args, *q.%s.To)", fieldName)
+		g.appendIfEnd()
+		g.restoreIntent(intent)
+	} else if op.arg != "" {
+		g.writeInstruction("if q.%s != nil && strings.TrimSpace(*q.%s) != \"\" {", fieldName, fieldName)
+		g.appendIfBody("arg := "+op.arg, fieldName)
+		g.appendIfBody(op.format, column, op.sign)
+		g.appendIfBody("args = append(args, arg)")
 	} else if strings.Contains(op.sign, "IN") {
+		ph := "?"
+		if strings.HasSuffix(op.name, core.IgnoreCase) {
+			ph = "LOWER(?)"
+		}
 		g.appendIfStartNil(fieldName)
 		g.appendIfBody("phs := make([]string, 0, len(*q.%s))", fieldName)
 		g.appendIfBody("for _, arg := range *q.%s {", fieldName)
 		g.appendIfBody("\targs = append(args, arg)")
-		g.appendIfBody("\tphs = append(phs, \"?\")")
+		g.appendIfBody("\tphs = append(phs, \"%s\")", ph)
 		g.appendIfBody("}")
 		g.appendIfBody(op.format, column, op.sign)
+	} else if strings.HasSuffix(fieldName, "Not") && toTypePointer(field) != nil {
+		g.appendIfStartNil(fieldName)
+		g.appendIfBody("if cond, args0 := BuildConditions(q.%s, \"NOT (\", \" AND \", \")\"); cond != \"\" {", fieldName)
+		g.appendIfBody("\tconditions = append(conditions, cond)")
+		g.appendIfBody("\targs = append(args, args0...)")
+		g.appendIfBody("}")
 	} else if strings.HasSuffix(fieldName, "Or") {
 		g.appendIfStartNil(fieldName)
 		g.appendIfBody("cond, args0 := BuildConditions(q.%s, \"(\", \" OR \", \")\")", fieldName)
 		g.appendIfBody("conditions = append(conditions, cond)")
 		g.appendIfBody("args = append(args, args0...)")
+	} else if strings.Contains(op.sign, "@>") || strings.Contains(op.sign, "&&") {
+		g.appendIfStartNil(fieldName)
+		g.appendIfBody(op.format, column, op.sign)
+		g.appendIfBody("args = append(args, Array(*q.%s))", fieldName)
 	} else {
 		g.appendIfStartNil(fieldName)
 		g.appendIfBody(op.format, column, op.sign)
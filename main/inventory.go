@@ -18,24 +18,27 @@ import (
 
 type InventoryQuery struct {
 	PageQuery
-	Id             *primitive.ObjectID
-	IdNe           *primitive.ObjectID
-	IdIn           *[]primitive.ObjectID
-	IdNotIn        *[]primitive.ObjectID
-	Qty            *int
-	QtyGt          *int
-	QtyLt          *int
-	QtyGe          *int
-	QtyLe          *int
-	Size           *SizeQuery
-	StatusNull     *bool
-	ItemContain    *string
-	ItemNotContain *string
-	ItemStart      *string
-	ItemNotStart   *string
-	ItemEnd        *string
-	ItemNotEnd     *string
-	CustomFilter   *primitive.M
+	Id                  *primitive.ObjectID
+	IdNe                *primitive.ObjectID
+	IdIn                *[]primitive.ObjectID
+	IdNotIn             *[]primitive.ObjectID
+	Qty                 *int
+	QtyGt               *int
+	QtyLt               *int
+	QtyGe               *int
+	QtyLe               *int
+	QtyBetween          *Range[int]
+	Size                *SizeQuery
+	StatusNull          *bool
+	ItemContain         *string
+	ItemNotContain      *string
+	ItemStart           *string
+	ItemNotStart        *string
+	ItemEnd             *string
+	ItemNotEnd          *string
+	ItemEqIgnoreCase    *string
+	ItemStartIgnoreCase *string
+	CustomFilter        *primitive.M
 	*QtyOr
 	Search *string
 }
@@ -1,6 +1,7 @@
 package main
 
 import . "go.mongodb.org/mongo-driver/bson/primitive"
+import "regexp"
 
 func (q InventoryQuery) BuildFilter() A {
 	d := make(A, 0, 4)
@@ -31,6 +32,14 @@ func (q InventoryQuery) BuildFilter() A {
 	if q.QtyLe != nil {
 		d = append(d, D{{"qty", D{{"$lte", q.QtyLe}}}})
 	}
+	if q.QtyBetween != nil {
+		if q.QtyBetween.From != nil {
+			d = append(d, D{{"qty", D{{"$gte", q.QtyBetween.From}}}})
+		}
+		if q.QtyBetween.To != nil {
+			d = append(d, D{{"qty", D{{"$lte", q.QtyBetween.To}}}})
+		}
+	}
 	if q.Size != nil {
 		d = append(d, q.Size.BuildFilter()...)
 	}
@@ -42,22 +51,28 @@ func (q InventoryQuery) BuildFilter() A {
 		}
 	}
 	if q.ItemContain != nil && *q.ItemContain != "" {
-		d = append(d, D{{"item", D{{"$regex", q.ItemContain}}}})
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemContain)}}}})
 	}
 	if q.ItemNotContain != nil && *q.ItemNotContain != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", q.ItemNotContain}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotContain)}}}}}})
 	}
 	if q.ItemStart != nil && *q.ItemStart != "" {
-		d = append(d, D{{"item", D{{"$regex", "^" + *q.ItemStart}}}})
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemStart)}}}})
 	}
 	if q.ItemNotStart != nil && *q.ItemNotStart != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", "^" + *q.ItemNotStart}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemNotStart)}}}}}})
 	}
 	if q.ItemEnd != nil && *q.ItemEnd != "" {
-		d = append(d, D{{"item", D{{"$regex", *q.ItemEnd + "$"}}}})
+		d = append(d, D{{"item", D{{"$regex", regexp.QuoteMeta(*q.ItemEnd) + "$"}}}})
 	}
 	if q.ItemNotEnd != nil && *q.ItemNotEnd != "" {
-		d = append(d, D{{"item", D{{"$not", D{{"$regex", *q.ItemNotEnd + "$"}}}}}})
+		d = append(d, D{{"item", D{{"$not", D{{"$regex", regexp.QuoteMeta(*q.ItemNotEnd) + "$"}}}}}})
+	}
+	if q.ItemEqIgnoreCase != nil {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemEqIgnoreCase) + "$"}, {"$options", "i"}}}})
+	}
+	if q.ItemStartIgnoreCase != nil && *q.ItemStartIgnoreCase != "" {
+		d = append(d, D{{"item", D{{"$regex", "^" + regexp.QuoteMeta(*q.ItemStartIgnoreCase)}, {"$options", "i"}}}})
 	}
 	if q.CustomFilter != nil {
 		d = append(d, *q.CustomFilter)
@@ -24,14 +24,16 @@ func (u UserEntity) GetTableName() string {
 
 type UserQuery struct {
 	PageQuery
-	IdGt     *int
-	IdIn     *[]int
-	IdNotIn  *[]int
-	Cond     *string `condition:"(score = ? OR memo = ?)"`
-	ScoreLt  *int
-	MemoNull *bool
-	MemoLike *string
-	Deleted  *bool
+	IdGt             *int
+	IdIn             *[]int
+	IdNotIn          *[]int
+	Cond             *string `condition:"(score = ? OR memo = ?)"`
+	ScoreLt          *int
+	ScoreBetween     *Range[int]
+	MemoNull         *bool
+	MemoLike         *string
+	MemoEqIgnoreCase *string
+	Deleted          *bool
 
 	ScoreLtAvg *UserQuery `subquery:"select avg(score) from User"`
 	ScoreLtAny *UserQuery `subquery:"SELECT score FROM User"`
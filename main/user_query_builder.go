@@ -35,6 +35,18 @@ func (q UserQuery) BuildConditions() ([]string, []any) {
 		conditions = append(conditions, "score < ?")
 		args = append(args, *q.ScoreLt)
 	}
+	if q.ScoreBetween != nil {
+		if q.ScoreBetween.From != nil && q.ScoreBetween.To != nil {
+			conditions = append(conditions, "score BETWEEN ? AND ?")
+			args = append(args, *q.ScoreBetween.From, *q.ScoreBetween.To)
+		} else if q.ScoreBetween.From != nil {
+			conditions = append(conditions, "score >= ?")
+			args = append(args, *q.ScoreBetween.From)
+		} else if q.ScoreBetween.To != nil {
+			conditions = append(conditions, "score <= ?")
+			args = append(args, *q.ScoreBetween.To)
+		}
+	}
 	if q.MemoNull != nil {
 		if *q.MemoNull {
 			conditions = append(conditions, "memo IS NULL")
@@ -42,9 +54,14 @@ func (q UserQuery) BuildConditions() ([]string, []any) {
 			conditions = append(conditions, "memo IS NOT NULL")
 		}
 	}
-	if q.MemoLike != nil {
-		conditions = append(conditions, "memo LIKE ?")
-		args = append(args, *q.MemoLike)
+	if q.MemoLike != nil && strings.TrimSpace(*q.MemoLike) != "" {
+		arg := *q.MemoLike
+		conditions = append(conditions, "memo LIKE ?"+EscapeClause(arg))
+		args = append(args, arg)
+	}
+	if q.MemoEqIgnoreCase != nil {
+		conditions = append(conditions, "LOWER(memo) = LOWER(?)")
+		args = append(args, *q.MemoEqIgnoreCase)
 	}
 	if q.Deleted != nil {
 		conditions = append(conditions, "deleted = ?")
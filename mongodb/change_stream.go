@@ -0,0 +1,68 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+)
+
+var changeOperations = map[string]string{
+	"insert":  "Create",
+	"update":  "Update",
+	"replace": "Update",
+	"delete":  "Delete",
+}
+
+type changeEvent[E any] struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   bson.M `bson:"documentKey"`
+	FullDocument  *E     `bson:"fullDocument"`
+}
+
+// BridgeChangeStream watches the collection of E and emits the changes
+// to the feed until ctx is done or the stream fails.
+// It is an alternative to NewChangeCapturedDataAccess capturing
+// the writes from all clients, and requires a replica set.
+func BridgeChangeStream[E MongoEntity](ctx context.Context, client *mongo.Client, feed *ChangeFeed) error {
+	entity := *new(E)
+	collection := client.Database(entity.Database()).Collection(entity.Collection())
+	stream, err := collection.Watch(ctx, mongo.Pipeline{}, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if HasError(err) {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	entityType := reflect.TypeOf(entity)
+	for stream.Next(ctx) {
+		var event changeEvent[E]
+		if err = stream.Decode(&event); HasError(err) {
+			return err
+		}
+		operation, ok := changeOperations[event.OperationType]
+		if !ok {
+			continue
+		}
+		record := ChangeRecord{EntityType: entityType, Operation: operation, Ids: []any{event.DocumentKey[MID]}}
+		if event.FullDocument != nil {
+			record.After = []any{*event.FullDocument}
+		}
+		feed.Emit(ctx, record)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return stream.Err()
+}
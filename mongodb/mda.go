@@ -37,18 +37,24 @@ type QueryBuilder interface {
 type mongoDataAccess[E MongoEntity] struct {
 	TransactionManager
 	collection *mongo.Collection
+	hooks      Hooks
+	tenant     *tenantMetadata
+	columns    []string
 }
 
-func NewMongoDataAccess[E MongoEntity](tm TransactionManager) TxDataAccess[E] {
+func NewMongoDataAccess[E MongoEntity](tm TransactionManager, interceptors ...Interceptor) TxDataAccess[E] {
 	entity := *new(E)
 	client := tm.GetClient().(*mongo.Client)
 	collection := client.Database(entity.Database()).Collection(entity.Collection())
 	entityType := reflect.TypeOf(entity)
 	createIndex(entityType, collection)
-	return &mongoDataAccess[E]{
+	return Intercept[E](&mongoDataAccess[E]{
 		TransactionManager: tm,
 		collection:         collection,
-	}
+		hooks:              HooksOf[E](),
+		tenant:             buildTenantMetadata(entityType),
+		columns:            bsonColumns(entityType),
+	}, interceptors...)
 }
 
 func createIndex(entityType reflect.Type, collection *mongo.Collection) {
@@ -87,11 +93,15 @@ func resolveColumnTag(columnTag string, fieldName string) (string, bool) {
 
 func (m *mongoDataAccess[E]) Get(ctx context.Context, id any) (*E, error) {
 	ID, err := ResolveId(id)
+	var filter D
 	if NoError(err) {
+		filter, err = m.withTenant(ctx, buildIdFilter(ID))
+	}
+	if err == nil {
 		e := *new(E)
-		err = m.collection.FindOne(ctx, buildIdFilter(ID)).Decode(&e)
+		err = m.collection.FindOne(ctx, filter).Decode(&e)
 		if NoError(err) {
-			return &e, err
+			return &e, CallAfterQuery(ctx, &e)
 		}
 	}
 	return nil, err
@@ -99,12 +109,44 @@ func (m *mongoDataAccess[E]) Get(ctx context.Context, id any) (*E, error) {
 
 func (m *mongoDataAccess[E]) Delete(ctx context.Context, id any) (int64, error) {
 	ID, err := ResolveId(id)
+	var filter D
 	if NoError(err) {
-		return unwrap(m.collection.DeleteOne(ctx, buildIdFilter(ID)))
+		filter, err = m.withTenant(ctx, buildIdFilter(ID))
+	}
+	if err == nil {
+		return m.doDelete(ctx, filter, func(ctx context.Context) (int64, error) {
+			return unwrap(m.collection.DeleteOne(ctx, filter))
+		})
 	}
 	return 0, err
 }
 
+// doDelete loads the entities to be deleted for the delete hooks only when required.
+func (m *mongoDataAccess[E]) doDelete(ctx context.Context, filter any, del func(ctx context.Context) (int64, error)) (int64, error) {
+	if !m.hooks.NeedDeleted() {
+		return del(ctx)
+	}
+	return SubmitWithHooks(ctx, m, m.hooks.AfterDelete, func(ctx context.Context) (int64, error) {
+		var entities []E
+		cursor, err := m.collection.Find(ctx, filter)
+		if NoError(err) {
+			err = cursor.All(ctx, &entities)
+		}
+		if HasError(err) {
+			return 0, err
+		}
+		ptrs := Pointers(entities)
+		if err = CallBeforeDelete(ctx, ptrs...); err != nil {
+			return 0, err
+		}
+		cnt, err := del(ctx)
+		if NoError(err) {
+			err = CallAfterDelete(ctx, ptrs...)
+		}
+		return cnt, err
+	})
+}
+
 func buildIdFilter(objectID any) D {
 	return D{{MID, objectID}}
 }
@@ -120,7 +162,10 @@ func ResolveId(id any) (ObjectID, error) {
 }
 
 func (m *mongoDataAccess[E]) Query(ctx context.Context, query Query) ([]E, error) {
-	filter := buildFilter(query)
+	filter, err := m.buildQueryFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 	return m.doQuery(ctx, query, filter)
 }
 
@@ -130,6 +175,9 @@ func (m *mongoDataAccess[E]) doQuery(ctx context.Context, query Query, filter D)
 	if NoError(err) {
 		err = cursor.All(ctx, &result)
 	}
+	if NoError(err) {
+		err = CallAfterQuery(ctx, Pointers(result)...)
+	}
 	return result, err
 }
 
@@ -150,20 +198,67 @@ func PInt64(i int) *int64 {
 	return &i64
 }
 
-func buildFilter(query Query) D {
+// buildFilter validates the query, including the sort by the columns,
+// and builds its filter.
+func buildFilter(query Query, columns []string) (D, error) {
+	if err := ValidateQuery(query, columns); err != nil {
+		return nil, err
+	}
 	if qb, ok := query.(QueryBuilder); ok {
 		d := qb.BuildFilter()
 		ret := D{}
 		if len(d) > 0 {
 			ret = D{{"$and", d}}
 		}
-		return ret
+		return ret, nil
 	}
 	panic(errors.New("Query object should be type of QueryBuilder"))
 }
 
+// bsonColumns returns the field names of the documents for the entity type,
+// named by the tag `bson` or the lowercase field name as the driver does.
+func bsonColumns(entityType reflect.Type) []string {
+	columns := make([]string, 0, entityType.NumField())
+	for i := 0; i < entityType.NumField(); i++ {
+		field := entityType.Field(i)
+		tags := strings.Split(field.Tag.Get("bson"), ",")
+		if tags[0] == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct && containsTag(tags[1:], "inline") && tags[0] == "" {
+			columns = append(columns, bsonColumns(field.Type)...)
+		} else if tags[0] != "" {
+			columns = append(columns, tags[0])
+		} else {
+			columns = append(columns, strings.ToLower(field.Name))
+		}
+	}
+	return columns
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// buildQueryFilter builds the filter of the query with the tenant.
+func (m *mongoDataAccess[E]) buildQueryFilter(ctx context.Context, query Query) (D, error) {
+	filter, err := buildFilter(query, m.columns)
+	if err != nil {
+		return nil, err
+	}
+	return m.withTenant(ctx, filter)
+}
+
 func (m *mongoDataAccess[E]) Count(ctx context.Context, query Query) (int64, error) {
-	filter := buildFilter(query)
+	filter, err := m.buildQueryFilter(ctx, query)
+	if err != nil {
+		return 0, err
+	}
 	return m.doCount(ctx, filter)
 }
 
@@ -172,7 +267,10 @@ func (m *mongoDataAccess[E]) doCount(ctx context.Context, filter D) (int64, erro
 }
 
 func (m *mongoDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (int64, error) {
-	filter := buildFilter(query)
+	filter, err := m.buildQueryFilter(ctx, query)
+	if err != nil {
+		return 0, err
+	}
 	if query.NeedPaging() {
 		IDs, err := m.doQueryIds(ctx, query, filter)
 		if HasError(err) {
@@ -180,11 +278,16 @@ func (m *mongoDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (in
 		}
 		filter = D{{MID, D{{"$in", IDs}}}}
 	}
-	return unwrap(m.collection.DeleteMany(ctx, filter))
+	return m.doDelete(ctx, filter, func(ctx context.Context) (int64, error) {
+		return unwrap(m.collection.DeleteMany(ctx, filter))
+	})
 }
 
 func (m *mongoDataAccess[E]) QueryIds(ctx context.Context, query Query) ([]any, error) {
-	filter := buildFilter(query)
+	filter, err := m.buildQueryFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 	return m.doQueryIds(ctx, query, filter)
 }
 
@@ -214,7 +317,10 @@ func unwrap(result *mongo.DeleteResult, err error) (int64, error) {
 
 func (m *mongoDataAccess[E]) Page(ctx context.Context, query Query) (PageList[E], error) {
 	var count int64
-	filter := buildFilter(query)
+	filter, err := m.buildQueryFilter(ctx, query)
+	if err != nil {
+		return PageList[E]{}, err
+	}
 	data, err := m.doQuery(ctx, query, filter)
 	if NoError(err) {
 		count, err = m.doCount(ctx, filter)
@@ -223,41 +329,93 @@ func (m *mongoDataAccess[E]) Page(ctx context.Context, query Query) (PageList[E]
 }
 
 func (m *mongoDataAccess[E]) Create(ctx context.Context, entity *E) (int64, error) {
-	result, err := m.collection.InsertOne(ctx, entity)
-	if NoError(err) {
-		err = (*entity).SetId(entity, result.InsertedID)
+	if err := m.fillTenant(ctx, entity); err != nil {
+		return 0, err
 	}
-	return 0, err
+	if err := CallBeforeCreate(ctx, entity); err != nil {
+		return 0, err
+	}
+	return SubmitWithHooks(ctx, m, m.hooks.AfterCreate, func(ctx context.Context) (int64, error) {
+		result, err := m.collection.InsertOne(ctx, entity)
+		if NoError(err) {
+			err = (*entity).SetId(entity, result.InsertedID)
+		}
+		if NoError(err) {
+			err = CallAfterCreate(ctx, entity)
+		}
+		return 0, err
+	})
 }
 
 func (m *mongoDataAccess[E]) CreateMulti(ctx context.Context, entities []E) (int64, error) {
-	docs := make([]any, len(entities))
-	for i := range entities {
-		docs[i] = entities[i]
+	ptrs := Pointers(entities)
+	if err := m.fillTenant(ctx, ptrs...); err != nil {
+		return 0, err
+	}
+	if err := CallBeforeCreate(ctx, ptrs...); err != nil {
+		return 0, err
 	}
+	return SubmitWithHooks(ctx, m, m.hooks.AfterCreate, func(ctx context.Context) (int64, error) {
+		docs := make([]any, len(entities))
+		for i := range entities {
+			docs[i] = entities[i]
+		}
 
-	result, err := m.collection.InsertMany(ctx, docs)
-	if NoError(err) {
-		for i, ID := range result.InsertedIDs {
-			err = entities[i].SetId(&entities[i], ID)
+		result, err := m.collection.InsertMany(ctx, docs)
+		if NoError(err) {
+			for i, ID := range result.InsertedIDs {
+				err = entities[i].SetId(&entities[i], ID)
+			}
+			if NoError(err) {
+				err = CallAfterCreate(ctx, ptrs...)
+			}
+			return int64(len(result.InsertedIDs)), err
 		}
-		return int64(len(result.InsertedIDs)), err
-	}
-	return 0, err
+		return 0, err
+	})
 }
 
 func (m *mongoDataAccess[E]) Update(ctx context.Context, entity E) (int64, error) {
-	result, err := m.collection.ReplaceOne(ctx, buildIdFilter(entity.GetId()), entity)
-	if NoError(err) {
-		return result.MatchedCount, err
-	}
-	return 0, err
+	return m.doUpdateWithHooks(ctx, &entity, func(ctx context.Context) (int64, error) {
+		filter, err := m.withTenant(ctx, buildIdFilter(entity.GetId()))
+		if err != nil {
+			return 0, err
+		}
+		result, err := m.collection.ReplaceOne(ctx, filter, entity)
+		if NoError(err) {
+			return result.MatchedCount, err
+		}
+		return 0, err
+	})
 }
 
 func (m *mongoDataAccess[E]) Patch(ctx context.Context, entity E) (int64, error) {
-	doc := buildPatch(entity)
-	idFilter := buildIdFilter(entity.GetId())
-	return unwrapPatch(m.collection.UpdateMany(ctx, idFilter, doc))
+	return m.doUpdateWithHooks(ctx, &entity, func(ctx context.Context) (int64, error) {
+		doc := buildPatch(entity)
+		idFilter, err := m.withTenant(ctx, buildIdFilter(entity.GetId()))
+		if err != nil {
+			return 0, err
+		}
+		return unwrapPatch(m.collection.UpdateMany(ctx, idFilter, doc))
+	})
+}
+
+// doUpdateWithHooks fills the tenant field to prevent
+// moving the entity to another tenant.
+func (m *mongoDataAccess[E]) doUpdateWithHooks(ctx context.Context, entity *E, update func(ctx context.Context) (int64, error)) (int64, error) {
+	if err := m.fillTenant(ctx, entity); err != nil {
+		return 0, err
+	}
+	if err := CallBeforeUpdate(ctx, entity); err != nil {
+		return 0, err
+	}
+	return SubmitWithHooks(ctx, m, m.hooks.AfterUpdate, func(ctx context.Context) (int64, error) {
+		cnt, err := update(ctx)
+		if NoError(err) {
+			err = CallAfterUpdate(ctx, entity)
+		}
+		return cnt, err
+	})
 }
 
 func buildPatch(entity any) M {
@@ -298,16 +456,21 @@ func readFieldName(field reflect.StructField) string {
 }
 
 func (m *mongoDataAccess[E]) PatchByQuery(ctx context.Context, entity E, query Query) (int64, error) {
-	doc := buildPatch(entity)
-	filter := buildFilter(query)
-	if query.NeedPaging() {
-		IDs, err := m.doQueryIds(ctx, query, filter)
-		if HasError(err) {
+	return m.doUpdateWithHooks(ctx, &entity, func(ctx context.Context) (int64, error) {
+		doc := buildPatch(entity)
+		filter, err := m.buildQueryFilter(ctx, query)
+		if err != nil {
 			return 0, err
 		}
-		filter = D{{MID, D{{"$in", IDs}}}}
-	}
-	return unwrapPatch(m.collection.UpdateMany(ctx, filter, doc))
+		if query.NeedPaging() {
+			IDs, err := m.doQueryIds(ctx, query, filter)
+			if HasError(err) {
+				return 0, err
+			}
+			filter = D{{MID, D{{"$in", IDs}}}}
+		}
+		return unwrapPatch(m.collection.UpdateMany(ctx, filter, doc))
+	})
 }
 
 func unwrapPatch(result *mongo.UpdateResult, err error) (int64, error) {
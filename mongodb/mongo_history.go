@@ -0,0 +1,70 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type historyDocument struct {
+	EntityId  any       `bson:"entity_id"`
+	Operation string    `bson:"operation"`
+	Before    string    `bson:"before"`
+	After     string    `bson:"after"`
+	Diff      string    `bson:"diff"`
+	Actor     string    `bson:"actor"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type mongoHistoryStore struct {
+	collection *mongo.Collection
+}
+
+// NewHistoryStore creates a HistoryStore for the entity E
+// stored in the collection `<collection>_history`.
+func NewHistoryStore[E MongoEntity](client *mongo.Client) HistoryStore {
+	entity := *new(E)
+	collection := client.Database(entity.Database()).Collection(entity.Collection() + "_history")
+	return &mongoHistoryStore{collection: collection}
+}
+
+func (s *mongoHistoryStore) Save(ctx context.Context, records ...HistoryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]any, len(records))
+	for i, r := range records {
+		docs[i] = historyDocument(r)
+	}
+	_, err := s.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (s *mongoHistoryStore) History(ctx context.Context, id any) ([]HistoryRecord, error) {
+	cursor, err := s.collection.Find(ctx, D{{"entity_id", id}}, options.Find().SetSort(D{{MID, 1}}))
+	if HasError(err) {
+		return nil, err
+	}
+	var docs []historyDocument
+	if err = cursor.All(ctx, &docs); HasError(err) {
+		return nil, err
+	}
+	records := make([]HistoryRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = HistoryRecord(doc)
+	}
+	return records, nil
+}
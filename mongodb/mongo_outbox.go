@@ -0,0 +1,76 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type outboxDocument struct {
+	Id        ObjectID   `bson:"_id,omitempty"`
+	Topic     string     `bson:"topic"`
+	Key       string     `bson:"key"`
+	Payload   []byte     `bson:"payload"`
+	CreatedAt time.Time  `bson:"created_at"`
+	SentAt    *time.Time `bson:"sent_at"`
+}
+
+type mongoOutbox struct {
+	collection *mongo.Collection
+}
+
+// NewOutbox creates an Outbox stored in the collection.
+func NewOutbox(collection *mongo.Collection) Outbox {
+	return &mongoOutbox{collection: collection}
+}
+
+func (o *mongoOutbox) Append(ctx context.Context, events ...OutboxEvent) error {
+	if _, ok := GetTransaction(ctx); !ok {
+		return ErrNoTransaction
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	now := time.Now()
+	docs := make([]any, len(events))
+	for i, event := range events {
+		docs[i] = outboxDocument{Topic: event.Topic, Key: event.Key, Payload: event.Payload, CreatedAt: now}
+	}
+	_, err := o.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (o *mongoOutbox) Fetch(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	opt := options.Find().SetSort(D{{MID, 1}}).SetLimit(int64(limit))
+	cursor, err := o.collection.Find(ctx, D{{"sent_at", nil}}, opt)
+	if HasError(err) {
+		return nil, err
+	}
+	var docs []outboxDocument
+	if err = cursor.All(ctx, &docs); HasError(err) {
+		return nil, err
+	}
+	events := make([]OutboxEvent, len(docs))
+	for i, doc := range docs {
+		events[i] = OutboxEvent{Id: doc.Id, Topic: doc.Topic, Key: doc.Key, Payload: doc.Payload, CreatedAt: doc.CreatedAt}
+	}
+	return events, nil
+}
+
+func (o *mongoOutbox) MarkSent(ctx context.Context, id any) error {
+	_, err := o.collection.UpdateByID(ctx, id, M{"$set": M{"sent_at": time.Now()}})
+	return err
+}
@@ -11,6 +11,7 @@
 package mongodb
 
 import (
+	. "github.com/doytowin/goooqo/core"
 	. "go.mongodb.org/mongo-driver/bson/primitive"
 	"reflect"
 	"testing"
@@ -35,3 +36,19 @@ func Test_buildSort(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildFilter(t *testing.T) {
+	columns := bsonColumns(reflect.TypeOf(InventoryEntity{}))
+	if !reflect.DeepEqual(columns, []string{"_id", "item", "size", "qty", "status"}) {
+		t.Errorf("bsonColumns() = %v", columns)
+	}
+
+	t.Run("Validate sort by columns", func(t *testing.T) {
+		sort := "qty,desc;name"
+		_, err := buildFilter(InventoryQuery{PageQuery: PageQuery{Sort: &sort}}, columns)
+		expect := "invalid query: Sort has unknown column: name"
+		if err == nil || err.Error() != expect {
+			t.Errorf("\nExpected: %s\nBut got : %v", expect, err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IsRetryable recognizes the errors labeled with
+// TransientTransactionError or UnknownTransactionCommitResult.
+func IsRetryable(err error) bool {
+	var le mongo.LabeledError
+	if errors.As(err, &le) {
+		return le.HasErrorLabel("TransientTransactionError") ||
+			le.HasErrorLabel("UnknownTransactionCommitResult")
+	}
+	return false
+}
+
+func (tm *mongoTransactionManager) IsRetryable(err error) bool {
+	return IsRetryable(err)
+}
@@ -12,8 +12,12 @@ package mongodb
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	. "github.com/doytowin/goooqo/core"
 	"go.mongodb.org/mongo-driver/mongo"
+	mopt "go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 )
 
 type mongoTransactionManager struct {
@@ -28,10 +32,22 @@ func (tm *mongoTransactionManager) GetClient() any {
 	return tm.client
 }
 
-func (tm *mongoTransactionManager) StartTransaction(ctx context.Context) (TransactionContext, error) {
+// StartTransaction supports the propagations except PropagationNested,
+// and maps sql.LevelSnapshot to the snapshot read concern.
+// Other isolation levels and ReadOnly are ignored.
+func (tm *mongoTransactionManager) StartTransaction(ctx context.Context, opts ...TxOption) (TransactionContext, error) {
+	options := BuildTxOptions(opts...)
+	switch options.Propagation {
+	case PropagationNotSupported:
+		return &NonTransactionContext{Context: suspend(ctx)}, nil
+	case PropagationNested:
+		return nil, fmt.Errorf("%w: nested transaction in MongoDB", ErrUnsupported)
+	case PropagationRequiresNew:
+		ctx = suspend(ctx)
+	}
 	ssnCtx, err := tm.resolveCtx(ctx)
 	if NoError(err) && !ssnCtx.active {
-		err = ssnCtx.StartTransaction()
+		err = ssnCtx.StartTransaction(buildTransactionOptions(options))
 		if NoError(err) {
 			ssnCtx.active = true
 		}
@@ -39,16 +55,47 @@ func (tm *mongoTransactionManager) StartTransaction(ctx context.Context) (Transa
 	return ssnCtx, err
 }
 
-func (tm *mongoTransactionManager) SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error) error {
-	tc, err := tm.StartTransaction(ctx)
+// suspend hides the transaction from ctx as well as its session,
+// which is still visible to the driver in a context derived from it.
+func suspend(ctx context.Context) context.Context {
+	ctx = SuspendTransaction(ctx)
+	if mongo.SessionFromContext(ctx) != nil {
+		ctx = mongo.NewSessionContext(ctx, nil)
+	}
+	return ctx
+}
+
+func buildTransactionOptions(options TxOptions) *mopt.TransactionOptions {
+	txOpt := mopt.Transaction()
+	if options.Isolation == sql.LevelSnapshot {
+		txOpt.SetReadConcern(readconcern.Snapshot())
+	}
+	return txOpt
+}
+
+// SubmitTransaction commits the transaction only when it is started here.
+// A joined call runs the callback directly, leaving the commit
+// or rollback to the outermost callback.
+func (tm *mongoTransactionManager) SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error, opts ...TxOption) error {
+	tc, err := tm.StartTransaction(ctx, opts...)
 	if NoError(err) {
+		if joined, ok := GetTransaction(ctx); ok && joined == tc {
+			return callback(tc)
+		}
 		err = TransactionCallback(tc, callback)
 	}
 	return err
 }
 
+// Supports reports false for all capabilities,
+// since MongoDB has no save point in transactions.
+func (tm *mongoTransactionManager) Supports(Capability) bool {
+	return false
+}
+
 func (tm *mongoTransactionManager) resolveCtx(ctx context.Context) (*mongoTransactionContext, error) {
-	ssnCtx, ok := ctx.(*mongoTransactionContext)
+	tc, _ := GetTransaction(ctx)
+	ssnCtx, ok := tc.(*mongoTransactionContext)
 	if !ok {
 		sess, err := tm.client.StartSession()
 		if HasError(err) {
@@ -65,26 +112,38 @@ func (tm *mongoTransactionManager) resolveCtx(ctx context.Context) (*mongoTransa
 
 type mongoTransactionContext struct {
 	mongo.SessionContext
+	TxCallbacks
 	active bool
 	parent context.Context
 }
 
+func (t *mongoTransactionContext) Value(key any) any {
+	if key == TransactionKey {
+		return t
+	}
+	return t.SessionContext.Value(key)
+}
+
 func (t *mongoTransactionContext) Parent() context.Context {
 	return t.parent
 }
 
 func (t *mongoTransactionContext) Commit() error {
-	return t.CommitTransaction(t.SessionContext)
+	err := t.CommitTransaction(t.SessionContext)
+	t.Complete(err == nil)
+	return err
 }
 
 func (t *mongoTransactionContext) Rollback() error {
-	return t.AbortTransaction(t.SessionContext)
+	err := t.AbortTransaction(t.SessionContext)
+	t.Complete(false)
+	return err
 }
 
-func (t *mongoTransactionContext) SavePoint(name string) error {
-	panic("not implemented")
+func (t *mongoTransactionContext) SavePoint(string) error {
+	return fmt.Errorf("%w: save point in MongoDB", ErrUnsupported)
 }
 
-func (t *mongoTransactionContext) RollbackTo(name string) error {
-	panic("not implemented")
+func (t *mongoTransactionContext) RollbackTo(string) error {
+	return fmt.Errorf("%w: save point in MongoDB", ErrUnsupported)
 }
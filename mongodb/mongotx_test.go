@@ -0,0 +1,71 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	. "github.com/doytowin/goooqo/core"
+	"go.mongodb.org/mongo-driver/mongo"
+	"testing"
+)
+
+func TestMongoTransaction(t *testing.T) {
+	tm := NewMongoTransactionManager(nil)
+
+	t.Run("Should not support save point", func(t *testing.T) {
+		tc := &mongoTransactionContext{}
+		err := tc.SavePoint("sp1")
+		if !(errors.Is(err, ErrUnsupported) && errors.Is(tc.RollbackTo("sp1"), ErrUnsupported)) {
+			t.Errorf("\nExpected: %s\nBut got : %v", ErrUnsupported, err)
+		}
+		if Supports(tm, CapabilitySavePoint) || Supports(tm, CapabilityNested) {
+			t.Error("Should not support save point")
+		}
+	})
+
+	t.Run("Should reject nested propagation", func(t *testing.T) {
+		_, err := tm.StartTransaction(context.Background(), WithPropagation(PropagationNested))
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("\nExpected: %s\nBut got : %v", ErrUnsupported, err)
+		}
+	})
+
+	t.Run("Should join transaction for context derived from transaction", func(t *testing.T) {
+		tc := &mongoTransactionContext{active: true, parent: context.Background()}
+		tc2, err := tm.StartTransaction(WithActor(tc, "alice"))
+		if !(err == nil && tc2 == tc) {
+			t.Errorf("Should join transaction: %v, %v", err, tc2)
+		}
+	})
+
+	t.Run("Should hide session for suspended transaction", func(t *testing.T) {
+		client, _ := mongo.Connect(context.Background())
+		defer client.Disconnect(context.Background())
+		sess, err := client.StartSession()
+		if HasError(err) {
+			t.Skip(err)
+		}
+		defer sess.EndSession(context.Background())
+		tc := &mongoTransactionContext{SessionContext: mongo.NewSessionContext(context.Background(), sess), active: true}
+		tc2, _ := tm.StartTransaction(WithActor(tc, "alice"), WithPropagation(PropagationNotSupported))
+		if _, ok := GetTransaction(tc2); ok || mongo.SessionFromContext(tc2) != nil || ActorOf(tc2) != "alice" {
+			t.Errorf("Should hide session: %v", tc2)
+		}
+	})
+
+	t.Run("Should retry for transient transaction error", func(t *testing.T) {
+		err := mongo.CommandError{Labels: []string{"TransientTransactionError"}}
+		if !(IsRetryable(err) && !IsRetryable(mongo.CommandError{})) {
+			t.Error("Should retry for TransientTransactionError")
+		}
+	})
+}
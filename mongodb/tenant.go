@@ -0,0 +1,66 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package mongodb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+)
+
+type tenantMetadata struct {
+	fieldName string
+	column    string
+}
+
+func buildTenantMetadata(entityType reflect.Type) *tenantMetadata {
+	for i := 0; i < entityType.NumField(); i++ {
+		field := entityType.Field(i)
+		if _, ok := field.Tag.Lookup("tenant"); ok {
+			return &tenantMetadata{field.Name, readFieldName(field)}
+		}
+	}
+	return nil
+}
+
+func (m *mongoDataAccess[E]) resolveTenant(ctx context.Context) (tenant any, ok bool, err error) {
+	if m.tenant == nil {
+		return nil, false, nil
+	}
+	tenant, bypass, err := ResolveTenant(ctx)
+	return tenant, err == nil && !bypass, err
+}
+
+// withTenant appends the tenant resolved from ctx to the filter.
+func (m *mongoDataAccess[E]) withTenant(ctx context.Context, filter D) (D, error) {
+	tenant, ok, err := m.resolveTenant(ctx)
+	if !ok {
+		return filter, err
+	}
+	return appendTenant(filter, m.tenant.column, tenant), nil
+}
+
+func appendTenant(filter D, column string, tenant any) D {
+	return append(filter, E{Key: column, Value: tenant})
+}
+
+// fillTenant sets the tenant from ctx to the tenant field of the entities.
+func (m *mongoDataAccess[E]) fillTenant(ctx context.Context, entities ...*E) error {
+	tenant, ok, err := m.resolveTenant(ctx)
+	if !ok {
+		return err
+	}
+	for _, entity := range entities {
+		AssignTenant(reflect.ValueOf(entity).Elem().FieldByName(m.tenant.fieldName), tenant)
+	}
+	return nil
+}
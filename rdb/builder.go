@@ -11,8 +11,12 @@
 package rdb
 
 import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	log "github.com/sirupsen/logrus"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 type QueryBuilder interface {
@@ -23,24 +27,121 @@ func isValidValue(value reflect.Value) bool {
 	return !value.IsNil()
 }
 
+// tenantColumns maps the tables of the tenant-aware entities to their tenant columns.
+var tenantColumns sync.Map
+
+func isTenantTable(table string) bool {
+	_, ok := tenantColumns.Load(table)
+	return ok
+}
+
+// scope renders the query objects for a DataAccess call,
+// and applies the tenant in ctx to every tenant-aware table
+// the query selects from, including the tables in subqueries.
+type scope struct {
+	ctx       context.Context
+	options   *Options
+	tenant    any
+	tenantErr error
+	bypass    bool
+	err       *error
+	// outer is the table of the query being rendered,
+	// correlated by the exists subqueries in the query.
+	outer string
+}
+
+// newScope resolves the tenant from ctx for the query objects.
+func newScope(ctx context.Context, options *Options) *scope {
+	tenant, bypass, err := ResolveTenant(ctx)
+	return &scope{ctx: ctx, options: options, tenant: tenant, bypass: bypass, tenantErr: err, err: new(error)}
+}
+
+// staticScope renders the query objects without the tenant conditions.
+func staticScope() *scope {
+	return &scope{ctx: context.Background(), options: BuildOptions(), bypass: true, err: new(error)}
+}
+
+// table resolves the table name by the TableResolver in the options.
+func (s *scope) table(name string) string {
+	return s.options.ResolveTable(s.ctx, name)
+}
+
+// validate records the errors of the query found by ValidateQuery.
+func (s *scope) validate(query any, columns []string) {
+	if err := ValidateQuery(query, columns); err != nil && *s.err == nil {
+		*s.err = err
+	}
+}
+
+// Err returns the error occurred while rendering, e.g., ErrTenantMissing.
+func (s *scope) Err() error {
+	return *s.err
+}
+
+// BuildWhereClause validates the query by ValidateQuery and logs the errors,
+// for the callers rendering the where clause without returning an error,
+// e.g., the generated QueryBuilder.
 func BuildWhereClause(query any) (string, []any) {
-	return BuildConditions(query, " WHERE ", " AND ", "")
+	whereClause, args, err := BuildValidWhereClause(query)
+	if err != nil {
+		log.Warn("Build where clause for ", err)
+	}
+	return whereClause, args
+}
+
+// BuildValidWhereClause returns the *ValidationError of the query
+// found by ValidateQuery along with the where clause.
+func BuildValidWhereClause(query any) (string, []any, error) {
+	s := staticScope()
+	s.validate(query, nil)
+	whereClause, args := s.buildConditions(query, " WHERE ", " AND ", "")
+	return whereClause, args, s.Err()
 }
 
 func BuildConditions(query any, prefix string, delimiter string, suffix string) (a string, args []any) {
-	var conditions []string
-	if qb, ok := query.(QueryBuilder); ok {
-		conditions, args = qb.BuildConditions()
-	} else {
-		conditions, args = buildConditions(query)
+	return staticScope().buildConditions(query, prefix, delimiter, suffix)
+}
+
+func (s *scope) buildConditions(query any, prefix string, delimiter string, suffix string) (string, []any) {
+	conditions, args := s.conditionsOf(s.outer, query)
+	return joinConditions(conditions, args, prefix, delimiter, suffix)
+}
+
+// tableConditions renders the conditions of the query selecting from the table,
+// followed by the tenant condition if the table is tenant-aware.
+func (s *scope) tableConditions(table string, query any, prefix string, delimiter string, suffix string) (string, []any) {
+	outer := s.outer
+	s.outer = table
+	conditions, args := s.conditionsOf(table, query)
+	s.outer = outer
+	if column, ok := tenantColumns.Load(table); ok && !s.bypass {
+		if s.tenantErr != nil {
+			*s.err = s.tenantErr
+		} else {
+			conditions = append(conditions, column.(string)+" = ?")
+			args = append(args, s.tenant)
+		}
 	}
+	return joinConditions(conditions, args, prefix, delimiter, suffix)
+}
+
+func joinConditions(conditions []string, args []any, prefix string, delimiter string, suffix string) (string, []any) {
 	if len(conditions) == 0 {
 		return "", []any{}
 	}
 	return prefix + strings.Join(conditions, delimiter) + suffix, args
 }
 
-func buildConditions(query any) ([]string, []any) {
+// conditionsOf prefers the generated QueryBuilder,
+// unless the tenant is required for the table queried.
+func (s *scope) conditionsOf(table string, query any) ([]string, []any) {
+	if qb, ok := query.(QueryBuilder); ok && (s.bypass || !isTenantTable(table)) {
+		return qb.BuildConditions()
+	}
+	return s.fieldConditions(query)
+}
+
+func (s *scope) fieldConditions(query any) ([]string, []any) {
 	rtype := reflect.TypeOf(query)
 	rvalue := reflect.ValueOf(query)
 	if rtype.Kind() == reflect.Pointer {
@@ -58,7 +159,7 @@ func buildConditions(query any) ([]string, []any) {
 		if processor != nil {
 			value := rvalue.FieldByName(field.Name)
 			if isValidValue(value) {
-				condition, arr := processor.Process(value.Elem())
+				condition, arr := processor.Process(s, value.Elem())
 				if condition != "" {
 					conditions = append(conditions, condition)
 					args = append(args, arr...)
@@ -11,6 +11,8 @@
 package rdb
 
 import (
+	"context"
+	"errors"
 	. "github.com/doytowin/goooqo/core"
 	. "github.com/doytowin/goooqo/test"
 	"reflect"
@@ -103,6 +105,34 @@ INTERSECT SELECT role_id FROM a_role_and_perm WHERE perm_id IN (SELECT id FROM t
 				"SELECT id FROM t_user WHERE score < ?))))",
 			[]any{80},
 		},
+		{
+			"Query User by Role with EXISTS",
+			UserQuery{RoleExists: &RoleQuery{Valid: P(true)}},
+			" WHERE EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id" +
+				" AND EXISTS (SELECT 1 FROM t_role WHERE t_role.id = a_user_and_role.role_id AND valid = ?))",
+			[]any{true},
+		},
+		{
+			"Query User without Role with NOT EXISTS",
+			UserQuery{RoleNotExists: &RoleQuery{}},
+			" WHERE NOT EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id" +
+				" AND EXISTS (SELECT 1 FROM t_role WHERE t_role.id = a_user_and_role.role_id))",
+			[]any{},
+		},
+		{
+			"Query User by created Role with EXISTS | one-to-many",
+			UserQuery{CreatedRoleExists: &RoleQuery{Id: P(2)}},
+			" WHERE EXISTS (SELECT 1 FROM t_role WHERE t_role.create_user_id = t_user.id AND id = ?)",
+			[]any{2},
+		},
+		{
+			"Query User by Permission with EXISTS",
+			UserQuery{PermExists: &PermQuery{Code: P("user:list")}},
+			" WHERE EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id" +
+				" AND EXISTS (SELECT 1 FROM a_role_and_perm WHERE a_role_and_perm.role_id = a_user_and_role.role_id" +
+				" AND EXISTS (SELECT 1 FROM t_perm WHERE t_perm.id = a_role_and_perm.perm_id AND code = ?)))",
+			[]any{"user:list"},
+		},
 	}
 	RegisterJoinTable("role", "user", "a_user_and_role")
 	RegisterJoinTable("menu", "perm", "a_perm_and_menu")
@@ -120,3 +150,58 @@ INTERSECT SELECT role_id FROM a_role_and_perm WHERE perm_id IN (SELECT id FROM t
 	}
 
 }
+
+type GoodUserEntity struct {
+	Int64Id
+	Score *int
+	Memo  *string
+}
+
+func (e GoodUserEntity) GetTableName() string {
+	return "v_good_user"
+}
+
+func TestBuildExistsForEntityTable(t *testing.T) {
+	em := buildEntityMetadata[GoodUserEntity]()
+
+	t.Run("Correlate with the table of the entity", func(t *testing.T) {
+		actual, _ := em.buildCount(staticScope(), UserQuery{RoleExists: &RoleQuery{}})
+		expect := "SELECT count(0) FROM v_good_user WHERE EXISTS (SELECT 1 FROM a_user_and_role " +
+			"WHERE a_user_and_role.user_id = v_good_user.id AND EXISTS (SELECT 1 FROM t_role " +
+			"WHERE t_role.id = a_user_and_role.role_id))"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Count by exists for the entity table", func(t *testing.T) {
+		db := Connect()
+		InitDB(db)
+		defer Disconnect(db)
+		_, _ = db.Exec(`drop view if exists v_good_user;
+create view v_good_user as select * from t_user where score > 60;`)
+		dataAccess := NewTxDataAccess[GoodUserEntity](NewTransactionManager(db))
+
+		cnt, err := dataAccess.Count(context.Background(), UserQuery{RoleExists: &RoleQuery{}})
+		if !(err == nil && cnt == 2) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+}
+
+func TestValidateQueryInBuilding(t *testing.T) {
+	em := buildEntityMetadata[UserEntity]()
+	s := staticScope()
+	_, _ = em.buildCount(s, UserQuery{PageQuery: PageQuery{Sort: P("name")}, MemoLike: P(" ")})
+
+	expect := "invalid query: MemoLike must not be blank; Sort has unknown column: name"
+	var validationError *ValidationError
+	if err := s.Err(); !errors.As(err, &validationError) || err.Error() != expect {
+		t.Errorf("\nExpected: %s\nBut got : %v", expect, err)
+	}
+	whereClause, _, err := BuildValidWhereClause(UserQuery{MemoLike: P(" ")})
+	expect = "invalid query: MemoLike must not be blank"
+	if !errors.As(err, &validationError) || err.Error() != expect || whereClause != "" {
+		t.Errorf("\nExpected: %s\nBut got : %v, %s", expect, err, whereClause)
+	}
+}
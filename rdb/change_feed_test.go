@@ -0,0 +1,95 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"errors"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"testing"
+)
+
+func TestChangeFeed(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	ctx := context.Background()
+	tm := NewTransactionManager(db)
+	feed := NewChangeFeed()
+	userDataAccess := NewChangeCapturedDataAccess(NewTxDataAccess[UserEntity](tm), feed)
+
+	var records []ChangeRecord
+	unsubscribe := feed.Subscribe(func(ctx context.Context, record ChangeRecord) {
+		records = append(records, record)
+	})
+	defer unsubscribe()
+
+	t.Run("Emit record with before and after values", func(t *testing.T) {
+		records = nil
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		_, err := userDataAccess.Patch(tc, UserEntity{Int64Id: NewInt64Id(2), Score: P(70)})
+		if !(err == nil && len(records) == 0) {
+			t.Fatalf("Should deliver after commit: %v, %v", err, records)
+		}
+		_ = tc.Commit()
+
+		record := records[0]
+		before, after := record.Before[0].(UserEntity), record.After[0].(UserEntity)
+		if !(len(records) == 1 && record.Operation == "Update" && record.EntityType.Name() == "UserEntity" &&
+			record.Ids[0] == int64(2) && *before.Score == 40 && *after.Score == 70 && *after.Memo == "Bad") {
+			t.Errorf("Unexpected: %v", records)
+		}
+	})
+
+	t.Run("Emit ids of entities deleted by query", func(t *testing.T) {
+		records = nil
+		cnt, err := userDataAccess.DeleteByQuery(ctx, UserQuery{ScoreLt: P(60)})
+		if !(err == nil && cnt == 1 && len(records) == 1 &&
+			records[0].Operation == "Delete" && len(records[0].Ids) == 1 && records[0].Ids[0] == int64(3)) {
+			t.Errorf("Unexpected: %v, %d, %v", err, cnt, records)
+		}
+	})
+
+	t.Run("Emit nothing after rollback", func(t *testing.T) {
+		records = nil
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			_, _ = userDataAccess.Create(tc, &UserEntity{Score: P(90)})
+			return errors.New("rollback")
+		})
+		if len(records) != 0 {
+			t.Errorf("Unexpected: %v", records)
+		}
+	})
+
+	t.Run("Emit created entity with id", func(t *testing.T) {
+		defer InitDB(db)
+		records = nil
+		entity := UserEntity{Score: P(90)}
+		id, err := userDataAccess.Create(ctx, &entity)
+		if !(err == nil && id == 5 && entity.Id == 5 && len(records) == 1 &&
+			records[0].Ids[0] == entity.Id && records[0].After[0].(UserEntity).Id == entity.Id) {
+			t.Errorf("Unexpected: %v, %d, %v", err, id, records)
+		}
+	})
+
+	t.Run("Emit entities created in batch", func(t *testing.T) {
+		defer InitDB(db)
+		records = nil
+		entities := []UserEntity{{Score: P(90)}, {Score: P(95)}}
+		cnt, err := userDataAccess.CreateMulti(ctx, entities)
+		if !(err == nil && cnt == 2 && len(records) == 1 && len(records[0].After) == 2 &&
+			*records[0].After[1].(UserEntity).Score == 95) {
+			t.Errorf("Unexpected: %v, %d, %v", err, cnt, records)
+		}
+	})
+}
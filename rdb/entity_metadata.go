@@ -27,103 +27,118 @@ type metadata struct {
 type EntityMetadata[E Entity] struct {
 	metadata
 	columnMetas     []FieldMetadata
+	columns         []string
 	relationMetas   []FieldMetadata
+	tenantMeta      *FieldMetadata
 	ColStr          string
-	fieldsWithoutId []string
-	createStr       string
+	fieldsWithoutId []FieldMetadata
+	insertStr       string
 	placeholders    string
-	updateStr       string
+	setStr          string
 }
 
 func RegisterEntity(entityName string, tableName string) {
 	emMap[entityName] = &metadata{TableName: tableName}
 }
 
-func (em *EntityMetadata[E]) buildArgs(entity E) []any {
+func (em *EntityMetadata[E]) buildArgs(s *scope, entity E) []any {
 	args := make([]any, len(em.fieldsWithoutId))
 	rv := reflect.ValueOf(entity)
-	for i, col := range em.fieldsWithoutId {
-		value := rv.FieldByName(col)
-		args[i] = ReadValue(value)
+	for i, fm := range em.fieldsWithoutId {
+		value := rv.FieldByName(fm.Field.Name)
+		args[i] = readColumnValue(s.options.QueryDialect, fm, value)
 	}
 	return args
 }
 
-func (em *EntityMetadata[E]) buildSelect(query Query) (string, []any) {
-	whereClause, args := BuildWhereClause(query)
-	s := "SELECT " + em.ColStr + " FROM " + em.TableName + whereClause
-	s += BuildSortClause(query.GetSort())
+func (em *EntityMetadata[E]) buildWhereClause(s *scope, query any) (string, []any) {
+	s.validate(query, em.columns)
+	return s.tableConditions(em.TableName, query, " WHERE ", " AND ", "")
+}
+
+func (em *EntityMetadata[E]) buildSelect(s *scope, query Query) (string, []any) {
+	whereClause, args := em.buildWhereClause(s, query)
+	sqlStr := "SELECT " + em.ColStr + " FROM " + s.table(em.TableName) + whereClause
+	orderBy, orderArgs := buildOrderBy(s, query)
+	sqlStr += orderBy
+	args = append(args, orderArgs...)
 	if query.NeedPaging() {
-		s = BuildPageClause(&s, query.CalcOffset(), query.GetPageSize())
+		sqlStr = BuildPageClause(&sqlStr, query.CalcOffset(), query.GetPageSize())
 	}
-	return s, args
+	return sqlStr, args
 }
 
-func (em *EntityMetadata[E]) buildSelectById() string {
-	return "SELECT " + em.ColStr + " FROM " + em.TableName + whereId
+func (em *EntityMetadata[E]) buildSelectForDelete(s *scope, query Query) (string, []any) {
+	whereClause, args := em.buildWhereClause(s, query)
+	return "SELECT " + em.ColStr + " FROM " + s.table(em.TableName) + whereClause, args
 }
 
-func (em *EntityMetadata[E]) buildCount(query Query) (string, []any) {
-	whereClause, args := BuildWhereClause(query)
-	sqlStr := "SELECT count(0) FROM " + em.TableName + whereClause
+func (em *EntityMetadata[E]) buildSelectById(s *scope) string {
+	return "SELECT " + em.ColStr + " FROM " + s.table(em.TableName) + whereId
+}
+
+func (em *EntityMetadata[E]) buildCount(s *scope, query Query) (string, []any) {
+	whereClause, args := em.buildWhereClause(s, query)
+	sqlStr := "SELECT count(0) FROM " + s.table(em.TableName) + whereClause
 	return sqlStr, args
 }
 
-func (em *EntityMetadata[E]) buildDeleteById() string {
-	return "DELETE FROM " + em.TableName + whereId
+func (em *EntityMetadata[E]) buildDeleteById(s *scope) string {
+	return "DELETE FROM " + s.table(em.TableName) + whereId
 }
 
-func (em *EntityMetadata[E]) buildDelete(query any) (string, []any) {
-	whereClause, args := BuildWhereClause(query)
-	sqlStr := "DELETE FROM " + em.TableName + whereClause
+func (em *EntityMetadata[E]) buildDelete(s *scope, query any) (string, []any) {
+	whereClause, args := em.buildWhereClause(s, query)
+	sqlStr := "DELETE FROM " + s.table(em.TableName) + whereClause
 	return sqlStr, args
 }
 
-func (em *EntityMetadata[E]) buildCreate(entity E) (string, []any) {
-	return em.createStr, em.buildArgs(entity)
+func (em *EntityMetadata[E]) buildCreate(s *scope, entity E) (string, []any) {
+	return "INSERT INTO " + s.table(em.TableName) + em.insertStr, em.buildArgs(s, entity)
 }
 
-func (em *EntityMetadata[E]) buildCreateMulti(entities []E) (string, []any) {
+func (em *EntityMetadata[E]) buildCreateMulti(s *scope, entities []E) (string, []any) {
 	args := make([]any, 0, len(entities)*len(em.fieldsWithoutId))
 	for _, entity := range entities {
-		args = append(args, em.buildArgs(entity)...)
+		args = append(args, em.buildArgs(s, entity)...)
 	}
-	createStr := em.createStr + strings.Repeat(", "+em.placeholders, len(entities)-1)
+	createStr := "INSERT INTO " + s.table(em.TableName) + em.insertStr +
+		strings.Repeat(", "+em.placeholders, len(entities)-1)
 	return createStr, args
 }
 
-func (em *EntityMetadata[E]) buildUpdate(entity E) (string, []any) {
-	args := em.buildArgs(entity)
+func (em *EntityMetadata[E]) buildUpdate(s *scope, entity E) (string, []any) {
+	args := em.buildArgs(s, entity)
 	args = append(args, entity.GetId())
-	return em.updateStr, args
+	return "UPDATE " + s.table(em.TableName) + em.setStr, args
 }
 
-func (em *EntityMetadata[E]) buildPatch(entity E, extra int) (string, []any) {
+func (em *EntityMetadata[E]) buildPatch(s *scope, entity E, extra int) (string, []any) {
 	args := make([]any, 0, len(em.fieldsWithoutId)+extra)
-	sqlStr := "UPDATE " + em.TableName + " SET "
+	sqlStr := "UPDATE " + s.table(em.TableName) + " SET "
 
 	rv := reflect.ValueOf(entity)
-	for _, col := range em.fieldsWithoutId {
-		value := rv.FieldByName(col)
-		v := ReadValue(value)
+	for _, fm := range em.fieldsWithoutId {
+		value := rv.FieldByName(fm.Field.Name)
+		v := readColumnValue(s.options.QueryDialect, fm, value)
 		if v != nil {
-			sqlStr += ConvertToColumnCase(col) + " = ?, "
+			sqlStr += fm.ColumnName + " = ?, "
 			args = append(args, v)
 		}
 	}
 	return sqlStr[0 : len(sqlStr)-2], args
 }
 
-func (em *EntityMetadata[E]) buildPatchById(entity E) (string, []any) {
-	sqlStr, args := em.buildPatch(entity, 1)
+func (em *EntityMetadata[E]) buildPatchById(s *scope, entity E) (string, []any) {
+	sqlStr, args := em.buildPatch(s, entity, 1)
 	sqlStr = sqlStr + whereId
 	args = append(args, entity.GetId())
 	return sqlStr, args
 }
 
-func (em *EntityMetadata[E]) buildPatchByQuery(entity E, query Query) (string, []any) {
-	whereClause, argsQ := BuildWhereClause(query)
-	patchClause, argsE := em.buildPatch(entity, len(argsQ))
+func (em *EntityMetadata[E]) buildPatchByQuery(s *scope, entity E, query Query) (string, []any) {
+	whereClause, argsQ := em.buildWhereClause(s, query)
+	patchClause, argsE := em.buildPatch(s, entity, len(argsQ))
 
 	args := append(argsE, argsQ...)
 	sqlStr := patchClause + whereClause
@@ -158,12 +173,16 @@ func buildEntityMetadata[E Entity]() EntityMetadata[E] {
 
 	columns := make([]string, len(columnMetas))
 	columnsWithoutId := make([]string, 0, len(columnMetas))
-	fieldsWithoutId := make([]string, 0, len(columnMetas))
+	fieldsWithoutId := make([]FieldMetadata, 0, len(columnMetas))
 
+	var tenantMeta *FieldMetadata
 	for i, md := range columnMetas {
 		columns[i] = md.ColumnName
+		if md.IsTenant {
+			tenantMeta = &columnMetas[i]
+		}
 		if !md.IsId {
-			fieldsWithoutId = append(fieldsWithoutId, md.Field.Name)
+			fieldsWithoutId = append(fieldsWithoutId, md)
 			columnsWithoutId = append(columnsWithoutId, md.ColumnName)
 		}
 	}
@@ -171,25 +190,28 @@ func buildEntityMetadata[E Entity]() EntityMetadata[E] {
 	tableName := FormatTableByEntity(entity)
 
 	placeholders := "(?" + strings.Repeat(", ?", len(columnsWithoutId)-1) + ")"
-	createStr := "INSERT INTO " + tableName +
-		" (" + strings.Join(columnsWithoutId, ", ") + ") " +
-		"VALUES " + placeholders
+	insertStr := " (" + strings.Join(columnsWithoutId, ", ") + ") VALUES " + placeholders
 
 	set := make([]string, len(columnsWithoutId))
 	for i, col := range columnsWithoutId {
 		set[i] = col + " = ?"
 	}
-	updateStr := "UPDATE " + tableName + " SET " + strings.Join(set, ", ") + whereId
+	setStr := " SET " + strings.Join(set, ", ") + whereId
 
 	RegisterEntity(entityType.Name(), tableName)
+	if tenantMeta != nil {
+		tenantColumns.Store(tableName, tenantMeta.ColumnName)
+	}
 	return EntityMetadata[E]{
 		metadata:        *emMap[entityType.Name()],
 		columnMetas:     columnMetas,
+		columns:         columns,
 		relationMetas:   relationMetas,
+		tenantMeta:      tenantMeta,
 		ColStr:          strings.Join(columns, ", "),
 		fieldsWithoutId: fieldsWithoutId,
-		createStr:       createStr,
+		insertStr:       insertStr,
 		placeholders:    placeholders,
-		updateStr:       updateStr,
+		setStr:          setStr,
 	}
 }
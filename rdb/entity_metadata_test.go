@@ -66,7 +66,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Select Statement", func(t *testing.T) {
 		query := UserQuery{IdGt: P(5), ScoreLt: P(60)}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE id > ? AND score < ?"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -78,7 +78,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Select Without Where", func(t *testing.T) {
 		query := UserQuery{}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -90,7 +90,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Select with Page Clause", func(t *testing.T) {
 		query := UserQuery{PageQuery: PageQuery{PageNumber: P(1), PageSize: P(10)}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user LIMIT 10 OFFSET 0"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -102,7 +102,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Select with Sort Clause", func(t *testing.T) {
 		query := UserQuery{PageQuery: PageQuery{PageSize: P(5), Sort: P("id")}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user ORDER BY id LIMIT 5 OFFSET 0"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -115,7 +115,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Count", func(t *testing.T) {
 		query := UserQuery{ScoreLt: P(60)}
-		actual, args := em.buildCount(&query)
+		actual, args := em.buildCount(staticScope(), &query)
 		expect := "SELECT count(0) FROM t_user WHERE score < ?"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -127,7 +127,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Create Stmt", func(t *testing.T) {
 		entity := UserEntity{Score: P(90), Memo: P("Great")}
-		actual, args := em.buildCreate(entity)
+		actual, args := em.buildCreate(staticScope(), entity)
 		expect := "INSERT INTO t_user (score, memo) VALUES (?, ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -139,7 +139,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Update Stmt", func(t *testing.T) {
 		entity := UserEntity{Int64Id: NewInt64Id(2), Score: P(90), Memo: P("Great")}
-		actual, args := em.buildUpdate(entity)
+		actual, args := em.buildUpdate(staticScope(), entity)
 		expect := "UPDATE t_user SET score = ?, memo = ? WHERE id = ?"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -151,7 +151,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Build Patch Stmt", func(t *testing.T) {
 		entity := UserEntity{Int64Id: NewInt64Id(2), Memo: P("Great")}
-		actual, args := em.buildPatchById(entity)
+		actual, args := em.buildPatchById(staticScope(), entity)
 		expect := "UPDATE t_user SET memo = ? WHERE id = ?"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -163,7 +163,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Support tag subquery", func(t *testing.T) {
 		query := UserQuery{ScoreLtAvg: &UserQuery{MemoLike: P("Well")}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score < (SELECT avg(score) FROM t_user WHERE memo LIKE ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -176,7 +176,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Support tag subquery with Any", func(t *testing.T) {
 		query := UserQuery{ScoreLtAny: &UserQuery{MemoLike: P("Well")}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score < ANY(SELECT score FROM t_user WHERE memo LIKE ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -189,7 +189,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Support tag subquery with All", func(t *testing.T) {
 		query := UserQuery{ScoreLtAll: &UserQuery{MemoLike: P("Well")}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score < ALL(SELECT score FROM t_user WHERE memo LIKE ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -202,7 +202,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Support tag select and from", func(t *testing.T) {
 		query := UserQuery{ScoreGtAvg: &UserQuery{MemoLike: P("Well")}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score > (SELECT avg(score) FROM t_user WHERE memo LIKE ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -215,7 +215,7 @@ func TestBuildStmt(t *testing.T) {
 
 	t.Run("Support subquery by fieldname: ScoreInScoreOfUser", func(t *testing.T) {
 		query := UserQuery{ScoreInScoreOfUser: &UserQuery{Deleted: P(true)}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score IN (SELECT score FROM t_user WHERE deleted = ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -230,7 +230,7 @@ func TestBuildStmt(t *testing.T) {
 		RegisterEntity("t_user", "t_user")
 
 		query := UserQuery{ScoreGtAvgScoreOfUser: &UserQuery{Deleted: P(true)}}
-		actual, args := em.buildSelect(&query)
+		actual, args := em.buildSelect(staticScope(), &query)
 		expect := "SELECT id, score, memo FROM t_user WHERE score > (SELECT AVG(score) FROM t_user WHERE deleted = ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -21,7 +21,7 @@ var fpMap = make(map[string]FieldProcessor)
 var fpTypeMap = make(map[reflect.Type]bool)
 
 type FieldProcessor interface {
-	Process(value reflect.Value) (string, []any)
+	Process(s *scope, value reflect.Value) (string, []any)
 }
 
 func buildFpKey(queryType reflect.Type, field reflect.StructField) string {
@@ -57,19 +57,40 @@ func registerFpByType(queryType reflect.Type) {
 			}
 		} else if strings.HasSuffix(field.Name, "And") {
 			fpMap[fpKey] = fpForAnd
+		} else if strings.HasSuffix(field.Name, "Not") && isStructOrArray(field.Type.Elem()) {
+			if field.Type.Elem().Kind() == reflect.Slice {
+				fpMap[fpKey] = buildFpStructArrayByNot()
+			} else {
+				fpMap[fpKey] = fpForNot
+			}
 		} else if field.Type.Implements(typeQuery) {
 			buildForQuery(field, fpKey)
 		} else if _, ok := field.Tag.Lookup("condition"); ok {
 			fpMap[fpKey] = buildFpCustom(field)
+		} else if _, ok := field.Tag.Lookup("search"); ok {
+			fpMap[fpKey] = buildFpSearch(field)
+		} else if path, _, _ := strings.Cut(field.Tag.Get("json"), ","); strings.HasPrefix(path, "$") {
+			fpMap[fpKey] = buildFpJsonPath(field.Name, path)
+		} else if isDateField(field) {
+			fpMap[fpKey] = buildFpDate(field.Name)
 		} else {
 			fpMap[fpKey] = buildFpSuffix(field.Name)
 		}
 	}
 }
 
+func isStructOrArray(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind() == reflect.Struct
+}
+
 func buildForQuery(field reflect.StructField, fpKey string) {
 	if _, ok := field.Tag.Lookup("entitypath"); ok {
 		fpMap[fpKey] = buildFpEntityPath(field)
+	} else if _, ok := field.Tag.Lookup("exists"); ok {
+		fpMap[fpKey] = BuildByExistsTag(field.Tag, field.Name)
 	} else if subqueryTag, ok := field.Tag.Lookup("subquery"); ok {
 		fpMap[fpKey] = BuildBySubqueryTag(subqueryTag, field.Name)
 	} else if _, ok := field.Tag.Lookup("select"); ok {
@@ -28,7 +28,7 @@ func buildFpCustom(field reflect.StructField) FieldProcessor {
 	return &fpCustom{&field, condition, phCnt}
 }
 
-func (fp *fpCustom) Process(value reflect.Value) (string, []any) {
+func (fp *fpCustom) Process(_ *scope, value reflect.Value) (string, []any) {
 	arr := make([]any, 0, fp.phCnt)
 	arg := ReadValue(value)
 	for j := 0; j < fp.phCnt; j++ {
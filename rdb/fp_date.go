@@ -0,0 +1,112 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// fpDate compares the date part of the time column `xxx_at`
+// for the time field named `XxxDate` with an optional suffix,
+// e.g., `CreatedDateGe` renders `DATE(created_at) >= ?`,
+// or `created_at >= ?` with the start of the date in UTC
+// for the dialect storing the time as text in UTC.
+type fpDate struct {
+	fpSuffix
+}
+
+func isDateField(field reflect.StructField) bool {
+	if !IsTimeType(field.Type) {
+		return false
+	}
+	name := field.Name
+	if match := SuffixRgx.FindStringSubmatch(name); len(match) > 0 {
+		name = strings.TrimSuffix(name, match[0])
+	}
+	return strings.HasSuffix(name, "Date")
+}
+
+func buildFpDate(fieldName string) FieldProcessor {
+	fp := buildFpSuffix(fieldName)
+	fp.col = strings.TrimSuffix(fp.col, "_date") + "_at"
+	return &fpDate{fp}
+}
+
+func (fp *fpDate) Process(s *scope, value reflect.Value) (string, []any) {
+	if s.options.QueryDialect.TimeAsText() && fp.op.isValid(value) {
+		if condition, args, ok := fp.processRange(value); ok {
+			return condition, args
+		}
+	}
+	fpSuffix := fp.fpSuffix
+	fpSuffix.col = s.options.QueryDialect.TruncDate(fp.col)
+	condition, args := fpSuffix.Process(s, value)
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			args[i] = t.In(Config.TimeLocation).Format("2006-01-02")
+		}
+	}
+	return condition, args
+}
+
+// processRange compares the time column with the range [start, end)
+// of the dates in Config.TimeLocation converted to UTC, for the dialect
+// storing the time as text in UTC, whose date part is the date in UTC.
+func (fp *fpDate) processRange(value reflect.Value) (string, []any, bool) {
+	col := fp.col
+	if fp.op.name == "Between" {
+		from, to := readBounds(value)
+		var conditions []string
+		var args []any
+		if start, _, ok := dayRange(from); ok {
+			conditions, args = append(conditions, col+" >= ?"), append(args, start)
+		}
+		if _, end, ok := dayRange(to); ok {
+			conditions, args = append(conditions, col+" < ?"), append(args, end)
+		}
+		return "(" + strings.Join(conditions, " AND ") + ")", args, len(args) > 0
+	}
+	_, values := fp.op.process(value)
+	if len(values) != 1 {
+		return "", nil, false
+	}
+	start, end, ok := dayRange(values[0])
+	switch fp.op.name {
+	case "Eq":
+		return "(" + col + " >= ? AND " + col + " < ?)", []any{start, end}, ok
+	case "Ne":
+		return "(" + col + " < ? OR " + col + " >= ?)", []any{start, end}, ok
+	case "Lt":
+		return col + " < ?", []any{start}, ok
+	case "Le":
+		return col + " < ?", []any{end}, ok
+	case "Gt":
+		return col + " >= ?", []any{end}, ok
+	case "Ge":
+		return col + " >= ?", []any{start}, ok
+	}
+	return "", nil, false
+}
+
+// dayRange returns the start of the date of arg in Config.TimeLocation
+// and the start of the next date, both in UTC.
+func dayRange(arg any) (time.Time, time.Time, bool) {
+	t, ok := arg.(time.Time)
+	if !ok {
+		return t, t, false
+	}
+	y, m, d := t.In(Config.TimeLocation).Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, Config.TimeLocation)
+	return start.UTC(), start.AddDate(0, 0, 1).UTC(), true
+}
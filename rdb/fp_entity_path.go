@@ -28,7 +28,7 @@ func BuildRelationEntityPath(field reflect.StructField) fpEntityPath {
 	return fpEntityPath{*BuildEntityPath(field)}
 }
 
-func (fp *fpEntityPath) Process(value reflect.Value) (string, []any) {
+func (fp *fpEntityPath) Process(s *scope, value reflect.Value) (string, []any) {
 	args := make([]any, 0)
 
 	l := len(fp.Path)
@@ -37,16 +37,17 @@ func (fp *fpEntityPath) Process(value reflect.Value) (string, []any) {
 	for i := 0; i < l-1; i++ {
 		queryValue := value.FieldByName(Capitalize(fp.Path[i]) + "Query")
 		if queryValue.IsValid() && !queryValue.IsNil() {
-			where0, args0 := BuildWhereClause(queryValue.Interface())
-			sql += "SELECT id FROM " + FormatTable(fp.Path[i]) + where0 + "\nINTERSECT "
+			table := FormatTable(fp.Path[i])
+			where0, args0 := s.tableConditions(table, queryValue.Interface(), " WHERE ", " AND ", "")
+			sql += "SELECT id FROM " + s.table(table) + where0 + "\nINTERSECT "
 			args = append(args, args0...)
 		}
 		relation := fp.Relations[i]
-		sql += "SELECT " + relation.Fk1 + " FROM " + relation.At + " WHERE " + relation.Fk2 + " IN ("
+		sql += "SELECT " + relation.Fk1 + " FROM " + s.table(relation.At) + " WHERE " + relation.Fk2 + " IN ("
 	}
-	where, args0 := BuildWhereClause(value.Interface())
+	where, args0 := s.tableConditions(fp.Base.At, value.Interface(), " WHERE ", " AND ", "")
 	args = append(args, args0...)
-	return sql + "SELECT " + fp.Base.Fk2 + " FROM " + fp.Base.At + where + closeParesis, args
+	return sql + "SELECT " + fp.Base.Fk2 + " FROM " + s.table(fp.Base.At) + where + closeParesis, args
 }
 
 func buildColumns(fieldMetas []FieldMetadata) string {
@@ -59,19 +60,19 @@ func buildColumns(fieldMetas []FieldMetadata) string {
 	return strings.Join(columns, ", ")
 }
 
-func (fp *fpEntityPath) buildSql(query Query) (string, []any) {
+func (fp *fpEntityPath) buildSql(s *scope, query Query) (string, []any) {
 	fieldMetas := BuildFieldMetas(fp.EntityType)
 	columns := buildColumns(fieldMetas)
 
-	s := "SELECT " + columns + " FROM " + fp.Base.At + " WHERE " + fp.Base.Fk2
+	sqlStr := "SELECT " + columns + " FROM " + s.table(fp.Base.At) + " WHERE " + fp.Base.Fk2
 	for i := len(fp.Relations) - 1; i >= 0; i-- {
 		relation := fp.Relations[i]
-		s += " IN (" + "SELECT " + relation.Fk2 + " FROM " + relation.At + " WHERE " + relation.Fk1 + " = ?)"
+		sqlStr += " IN (" + "SELECT " + relation.Fk2 + " FROM " + s.table(relation.At) + " WHERE " + relation.Fk1 + " = ?)"
 	}
-	and, args := BuildConditions(query, " AND ", " AND ", "")
-	s += and + BuildSortClause(query.GetSort())
+	and, args := s.tableConditions(fp.Base.At, query, " AND ", " AND ", "")
+	sqlStr += and + BuildSortClause(query.GetSort())
 	if query.NeedPaging() {
-		s = BuildPageClause(&s, query.CalcOffset(), query.GetPageSize())
+		sqlStr = BuildPageClause(&sqlStr, query.CalcOffset(), query.GetPageSize())
 	}
-	return s, args
+	return sqlStr, args
 }
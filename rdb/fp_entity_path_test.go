@@ -44,7 +44,7 @@ func Test_fpEntityPath_buildSql(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fp := BuildRelationEntityPath(tt.field)
-			got, got1 := fp.buildSql(tt.query)
+			got, got1 := fp.buildSql(staticScope(), tt.query)
 			if got != tt.want {
 				t.Errorf("buildSql()\n got : %v,\n want: %v", got, tt.want)
 			}
@@ -0,0 +1,103 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"strings"
+)
+
+// fpExists maps a query field with the tag `exists` to a correlated subquery,
+// e.g., `exists:"user,role"` for the field `RoleExists *RoleQuery` maps to
+//
+//	EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id
+//	AND EXISTS (SELECT 1 FROM t_role WHERE t_role.id = a_user_and_role.role_id AND ...))
+//
+// and the tag `foreignField` correlates the last table with the first one directly.
+// A field name ending with `NotExists` maps to NOT EXISTS.
+// The first table is replaced by the table of the outer query when rendered in it.
+type fpExists struct {
+	not   bool
+	hops  []existsHop
+	table string
+	first string
+}
+
+// existsHop correlates the column of the table in a subquery
+// with the column of the outer table, empty for the first table.
+type existsHop struct {
+	table, column      string
+	outer, outerColumn string
+}
+
+func BuildByExistsTag(tag reflect.StructTag, fieldName string) *fpExists {
+	path := strings.Split(tag.Get("exists"), ",")
+	localField := ConvertToColumnCase(tag.Get("localField"))
+	if localField == "" {
+		localField = "id"
+	}
+	first := FormatTable(path[0])
+	last := FormatTable(path[len(path)-1])
+
+	fp := &fpExists{not: strings.HasSuffix(fieldName, "NotExists"), table: last, first: first}
+	if foreignField := tag.Get("foreignField"); foreignField != "" {
+		fp.hops = append(fp.hops, existsHop{last, ConvertToColumnCase(foreignField), "", localField})
+	} else {
+		outer, outerColumn := "", localField
+		for i := 0; i < len(path)-1; i++ {
+			relation := BuildRelation(path[i], path[i+1])
+			fp.hops = append(fp.hops, existsHop{relation.At, relation.Fk1, outer, outerColumn})
+			outer, outerColumn = relation.At, relation.Fk2
+		}
+		fp.hops = append(fp.hops, existsHop{last, "id", outer, outerColumn})
+	}
+	return fp
+}
+
+// render renders the subqueries correlated with the outer table,
+// and the table names resolved by resolve, so that the qualified
+// columns refer to the resolved tables as well.
+func (fp *fpExists) render(outer string, resolve func(table string) string) string {
+	hops := make([]string, len(fp.hops))
+	for i, hop := range fp.hops {
+		table := resolve(hop.table)
+		if hop.outer != "" {
+			outer = hop.outer
+		}
+		hops[i] = "EXISTS (SELECT 1 FROM " + table + " WHERE " +
+			table + "." + hop.column + " = " + resolve(outer) + "." + hop.outerColumn
+	}
+	prefix := strings.Join(hops, " AND ")
+	if fp.not {
+		prefix = "NOT " + prefix
+	}
+	return prefix
+}
+
+// Subquery returns the condition before the conditions of the query.
+func (fp *fpExists) Subquery() string {
+	return fp.render(fp.first, func(table string) string { return table })
+}
+
+// Closing returns the parentheses closing the subqueries.
+func (fp *fpExists) Closing() string {
+	return strings.Repeat(")", len(fp.hops))
+}
+
+func (fp *fpExists) Process(s *scope, value reflect.Value) (string, []any) {
+	and, args := s.tableConditions(fp.table, value.Interface(), " AND ", " AND ", "")
+	outer := fp.first
+	if s.outer != "" {
+		outer = s.outer
+	}
+	return fp.render(outer, s.table) + and + fp.Closing(), args
+}
@@ -0,0 +1,33 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"reflect"
+)
+
+// fpJsonPath renders the condition on the text extracted by
+// the JSON path in the tag, e.g., `json:"$.address.city"`,
+// from the column named after the field.
+type fpJsonPath struct {
+	fpSuffix
+	path string
+}
+
+func buildFpJsonPath(fieldName string, path string) FieldProcessor {
+	return &fpJsonPath{buildFpSuffix(fieldName), path}
+}
+
+func (fp *fpJsonPath) Process(s *scope, value reflect.Value) (string, []any) {
+	fpSuffix := fp.fpSuffix
+	fpSuffix.col = s.options.QueryDialect.JsonPath(fp.col, fp.path)
+	return fpSuffix.Process(s, value)
+}
@@ -28,7 +28,31 @@ var fpForAnd = &fpMultiConditions{connect: func(conditions []string) string {
 	return strings.Join(conditions, " AND ")
 }}
 
-func (fp *fpMultiConditions) Process(value reflect.Value) (string, []any) {
-	conditions, args := buildConditions(value.Interface())
+var fpForNot = &fpMultiConditions{connect: func(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "NOT (" + strings.Join(conditions, " AND ") + ")"
+}}
+
+func (fp *fpMultiConditions) Process(s *scope, value reflect.Value) (string, []any) {
+	conditions, args := s.fieldConditions(value.Interface())
 	return fp.connect(conditions), args
 }
+
+// negate the conditions mapped by a struct array connected by OR
+type fpStructArrayByNot struct {
+	fpStructArrayByOr FieldProcessor
+}
+
+func buildFpStructArrayByNot() FieldProcessor {
+	return &fpStructArrayByNot{buildFpStructArrayByOr()}
+}
+
+func (fp *fpStructArrayByNot) Process(s *scope, value reflect.Value) (string, []any) {
+	if value.Len() == 0 {
+		return "", []any{}
+	}
+	condition, args := fp.fpStructArrayByOr.Process(s, value)
+	return "NOT " + condition, args
+}
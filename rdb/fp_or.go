@@ -23,11 +23,11 @@ func buildFpBasicArrayByOr(fieldName string) FieldProcessor {
 	return &fpBasicArrayByOr{fpSuffix: buildFpSuffix(strings.TrimSuffix(fieldName, "Or"))}
 }
 
-func (fp *fpBasicArrayByOr) Process(value reflect.Value) (string, []any) {
+func (fp *fpBasicArrayByOr) Process(s *scope, value reflect.Value) (string, []any) {
 	var args, arr []any
 	conditions := make([]string, value.Len())
 	for i := 0; i < value.Len(); i++ {
-		conditions[i], arr = fp.fpSuffix.Process(value.Index(i))
+		conditions[i], arr = fp.fpSuffix.Process(s, value.Index(i))
 		args = append(args, arr...)
 	}
 	return fpForOr.connect(conditions), args
@@ -41,11 +41,11 @@ func buildFpStructArrayByOr() FieldProcessor {
 	return &fpStructArrayByOr{fpForAnd}
 }
 
-func (fp *fpStructArrayByOr) Process(value reflect.Value) (condition string, args []any) {
+func (fp *fpStructArrayByOr) Process(s *scope, value reflect.Value) (condition string, args []any) {
 	conditions := make([]string, value.Len())
 	var arr []any
 	for i := 0; i < value.Len(); i++ {
-		conditions[i], arr = fp.fpForAnd.Process(value.Index(i))
+		conditions[i], arr = fp.fpForAnd.Process(s, value.Index(i))
 		args = append(args, arr...)
 	}
 	return fpForOr.connect(conditions), args
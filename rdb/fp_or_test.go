@@ -19,7 +19,7 @@ import (
 func TestOr(t *testing.T) {
 
 	t.Run("Build Or Condition", func(t *testing.T) {
-		actual, _ := fpForOr.Process(reflect.ValueOf(&TestCond{Username: P("f0rb"), Email: P("f0rb")}))
+		actual, _ := fpForOr.Process(staticScope(), reflect.ValueOf(&TestCond{Username: P("f0rb"), Email: P("f0rb")}))
 		expect := "(username = ? OR email = ?)"
 		if actual != expect {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
@@ -75,5 +75,37 @@ func TestOr(t *testing.T) {
 			t.Errorf("Unexpected args: %v", args)
 		}
 	})
+}
 
+func TestNot(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  TestQuery
+		expect string
+		args   []any
+	}{
+		{"Build NOT Clause for struct",
+			TestQuery{TestNot: &TestCond{Username: P("f0rb"), Email: P("f0rb")}, Deleted: P(true)},
+			" WHERE NOT (username = ? AND email = ?) AND deleted = ?", []any{"f0rb", "f0rb", true}},
+		{"Build NOT Clause with And",
+			TestQuery{TestNot: &TestCond{Username: P("f0rb"), TestAnd: &TestCond{Email: P("f0rb")}}},
+			" WHERE NOT (username = ? AND email = ?)", []any{"f0rb", "f0rb"}},
+		{"Build NOT Clause for struct array",
+			TestQuery{TestsNot: &[]TestCond{{Username: P("f0rb")}, {Username: P("test2"), Email: P("test2@qq.com")}}},
+			" WHERE NOT (username = ? OR username = ? AND email = ?)", []any{"f0rb", "test2", "test2@qq.com"}},
+		{"Ignore empty NOT Clause",
+			TestQuery{TestNot: &TestCond{}, TestsNot: &[]TestCond{}, Deleted: P(true)},
+			" WHERE deleted = ?", []any{true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, args := BuildWhereClause(tt.query)
+			if actual != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, actual)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Unexpected args: %v", args)
+			}
+		})
+	}
 }
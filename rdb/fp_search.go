@@ -0,0 +1,82 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"strings"
+)
+
+// fpSearch maps a query field with the tag `search` listing the columns,
+// e.g., `search:"title,content"`, to the full-text condition of the dialect,
+// and the tag `rank` orders the results by relevance before the sort.
+type fpSearch struct {
+	columns []string
+}
+
+func buildFpSearch(field reflect.StructField) FieldProcessor {
+	return &fpSearch{strings.Split(field.Tag.Get("search"), ",")}
+}
+
+func (fp *fpSearch) Process(s *scope, value reflect.Value) (string, []any) {
+	if !isNotBlank(value) {
+		return "", []any{}
+	}
+	condition := s.options.QueryDialect.FullText(fp.columns)
+	return condition, repeatArg(value.String(), strings.Count(condition, "?"))
+}
+
+func repeatArg(arg any, n int) []any {
+	args := make([]any, n)
+	for i := range args {
+		args[i] = arg
+	}
+	return args
+}
+
+// buildRankClause builds the ordering by relevance
+// for the assigned search fields tagged with `rank`.
+func buildRankClause(dialect QueryDialect, query any) ([]string, []any) {
+	rv := reflect.Indirect(reflect.ValueOf(query))
+	if rv.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var orderBy []string
+	var args []any
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		value := rv.Field(i)
+		if _, ok := field.Tag.Lookup("rank"); !ok || value.Kind() != reflect.Pointer || value.IsNil() {
+			continue
+		}
+		search, ok := field.Tag.Lookup("search")
+		if !ok || !isNotBlank(value.Elem()) {
+			continue
+		}
+		if rank := dialect.FullTextRank(strings.Split(search, ",")); rank != "" {
+			orderBy = append(orderBy, rank)
+			args = append(args, repeatArg(value.Elem().String(), strings.Count(rank, "?"))...)
+		}
+	}
+	return orderBy, args
+}
+
+func buildOrderBy(s *scope, query Query) (string, []any) {
+	orderBy, args := buildRankClause(s.options.QueryDialect, query)
+	if len(orderBy) == 0 {
+		return BuildSortClause(query.GetSort()), args
+	}
+	if sort := BuildSortClause(query.GetSort()); sort != "" {
+		orderBy = append(orderBy, strings.TrimPrefix(sort, " ORDER BY "))
+	}
+	return " ORDER BY " + strings.Join(orderBy, ", "), args
+}
@@ -0,0 +1,93 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"testing"
+)
+
+type PostEntity struct {
+	Int64Id
+	Title   *string
+	Content *string
+}
+
+func (e PostEntity) GetTableName() string {
+	return "t_post"
+}
+
+type PostQuery struct {
+	PageQuery
+	Search    *string `search:"title,content" rank:""`
+	SearchAll *string `search:"t_post"`
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("Render full-text search by dialect", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			dialect QueryDialect
+			expect  string
+			args    []any
+		}{
+			{"SQLite", SQLiteQueryDialect,
+				"SELECT id, title, content FROM t_post WHERE (title MATCH ? OR content MATCH ?) ORDER BY rank, id DESC",
+				[]any{"go", "go"}},
+			{"MySQL", MySQLQueryDialect,
+				"SELECT id, title, content FROM t_post WHERE MATCH(title, content) AGAINST(?) " +
+					"ORDER BY MATCH(title, content) AGAINST(?) DESC, id DESC",
+				[]any{"go", "go"}},
+			{"Postgres", PostgresQueryDialect,
+				"SELECT id, title, content FROM t_post " +
+					"WHERE to_tsvector(coalesce(title, '') || ' ' || coalesce(content, '')) @@ plainto_tsquery(?) " +
+					"ORDER BY ts_rank(to_tsvector(coalesce(title, '') || ' ' || coalesce(content, '')), plainto_tsquery(?)) DESC, id DESC",
+				[]any{"go", "go"}},
+		}
+		em := buildEntityMetadata[PostEntity]()
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				s := newScope(context.Background(), BuildOptions(WithQueryDialect(tt.dialect)))
+				actual, args := em.buildSelect(s, PostQuery{PageQuery: PageQuery{Sort: P("id,desc")}, Search: P("go")})
+				if actual != tt.expect {
+					t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, actual)
+				}
+				if !reflect.DeepEqual(args, tt.args) {
+					t.Errorf("\nExpected: %v\nBut got : %v", tt.args, args)
+				}
+			})
+		}
+	})
+
+	t.Run("Ignore blank search text", func(t *testing.T) {
+		actual, args := BuildWhereClause(PostQuery{Search: P(" ")})
+		if !(actual == "" && len(args) == 0) {
+			t.Errorf("Unexpected: %s, %v", actual, args)
+		}
+	})
+
+	t.Run("Search by FTS table in SQLite", func(t *testing.T) {
+		db := Connect()
+		defer Disconnect(db)
+		_, _ = db.Exec(`drop table if exists t_post;
+create virtual table t_post using fts4(id, title, content);
+INSERT INTO t_post(id, title, content) VALUES (1, 'Go generics', 'type parameters'), (2, 'SQL joins', 'inner join in go'), (3, 'Rust', 'ownership');`)
+		dataAccess := NewTxDataAccess[PostEntity](NewTransactionManager(db))
+
+		posts, err := dataAccess.Query(context.Background(), PostQuery{SearchAll: P("go")})
+		cnt, err2 := dataAccess.Count(context.Background(), PostQuery{SearchAll: P("ownership")})
+		if !(err == nil && err2 == nil && len(posts) == 2 && cnt == 1) {
+			t.Errorf("Unexpected: %v, %v, %v, %d", err, err2, posts, cnt)
+		}
+	})
+}
@@ -22,22 +22,24 @@ type fpSubquery struct {
 	select_, from string
 }
 
-func (fp *fpSubquery) Process(value reflect.Value) (string, []any) {
-	where, args := BuildWhereClause(value.Interface())
-	return fp.buildCondition(where), args
+func (fp *fpSubquery) Process(s *scope, value reflect.Value) (string, []any) {
+	where, args := s.tableConditions(fp.table(), value.Interface(), " WHERE ", " AND ", "")
+	return fp.subquery(s.table(fp.table())) + where + ")", args
 }
 
-func (fp *fpSubquery) Subquery() string {
+func (fp *fpSubquery) table() string {
 	if em := emMap[fp.from]; em != nil {
-		fp.from = em.TableName
-	} else {
-		fp.from = core.FormatTable(core.ConvertToColumnCase(fp.from))
+		return em.TableName
 	}
-	return fp.column + fp.sign + "(SELECT " + fp.select_ + " FROM " + fp.from
+	return core.FormatTable(core.ConvertToColumnCase(fp.from))
+}
+
+func (fp *fpSubquery) Subquery() string {
+	return fp.subquery(fp.table())
 }
 
-func (fp *fpSubquery) buildCondition(where string) string {
-	return fp.Subquery() + where + ")"
+func (fp *fpSubquery) subquery(table string) string {
+	return fp.column + fp.sign + "(SELECT " + fp.select_ + " FROM " + table
 }
 
 var sqRegx = regexp.MustCompile(`(?i)(select|from)[\s:]([\w()]+)`)
@@ -64,7 +66,7 @@ func BuildBySelectTag(tag reflect.StructTag, fieldName string) *fpSubquery {
 	return fp
 }
 
-var subOfRgx = regexp.MustCompile("(\\w+(Any|All|" + core.SuffixStr + "))(([A-Z]\\w+)Of([A-Z]\\w+))")
+var subOfRgx = buildSubOfRgx()
 var aggregateRgx = regexp.MustCompile("(Avg|Max|Min|Sum|First|Last|Push)(\\w+)")
 
 func BuildByFieldName(match []string) *fpSubquery {
@@ -97,7 +99,15 @@ func (fp *fpSubquery) buildComp(fieldName string) {
 	return
 }
 
-var fieldRgx = regexp.MustCompile("(\\w+(" + core.SuffixStr + "))[A-Z\\d]")
+var fieldRgx = buildFieldRgx()
+
+func buildSubOfRgx() *regexp.Regexp {
+	return regexp.MustCompile("(\\w+(Any|All|" + core.SuffixStr + "))(([A-Z]\\w+)Of([A-Z]\\w+))")
+}
+
+func buildFieldRgx() *regexp.Regexp {
+	return regexp.MustCompile("(\\w+(" + core.SuffixStr + "))[A-Z\\d]")
+}
 
 // Trim tailing string after the predicate suffix.
 // Examples:
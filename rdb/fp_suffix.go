@@ -69,11 +69,48 @@ func BuildArgsForIn(value reflect.Value) (string, []any) {
 	return ph.String(), args
 }
 
+func readBounds(value reflect.Value) (any, any) {
+	if bounded, ok := value.Interface().(Bounded); ok {
+		return bounded.Bounds()
+	}
+	return nil, nil
+}
+
+func isBounded(value reflect.Value) bool {
+	from, to := readBounds(value)
+	return from != nil || to != nil
+}
+
+func BuildArgsForBetween(value reflect.Value) (string, []any) {
+	from, to := readBounds(value)
+	if from == nil {
+		return " <= ?", []any{to}
+	} else if to == nil {
+		return " >= ?", []any{from}
+	}
+	return " BETWEEN ? AND ?", []any{from, to}
+}
+
+// BuildArgsForArray passes the value as an array for the array operators.
+func BuildArgsForArray(value reflect.Value) (string, []any) {
+	return "?", []any{Array(value.Interface())}
+}
+
 func ReadLikeValue(value reflect.Value) string {
-	s := value.String()
+	return EscapeLike(value.String())
+}
+
+// EscapeLike escapes the wildcards in s for LIKE.
+func EscapeLike(s string) string {
 	return escapeRgx.ReplaceAllString(s, "\\$0")
 }
 
+// EscapeClause returns the ESCAPE clause for the LIKE pattern
+// containing the escape character, or an empty string.
+func EscapeClause(pattern string) string {
+	return strings.TrimPrefix(resolvePlaceHolder(pattern), "?")
+}
+
 func CreateOpMap() map[string]operator {
 	const Like = " LIKE "
 	const NotLike = " NOT LIKE "
@@ -82,6 +119,7 @@ func CreateOpMap() map[string]operator {
 	opMap["Ge"] = operator{"Ge", " >= ", ReadValueToArray, ok}
 	opMap["Lt"] = operator{"Lt", " < ", ReadValueToArray, ok}
 	opMap["Le"] = operator{"Le", " <= ", ReadValueToArray, ok}
+	opMap["Between"] = operator{"Between", "", BuildArgsForBetween, isBounded}
 	opMap["Ne"] = operator{"Ne", " <> ", ReadValueToArray, ok}
 	opMap["Eq"] = operator{"Eq", " = ", ReadValueToArray, ok}
 	opMap["Null"] = operator{"Null", "", func(rv reflect.Value) (string, []any) {
@@ -136,6 +174,20 @@ func CreateOpMap() map[string]operator {
 	return opMap
 }
 
+// RegisterOperator registers a custom suffix operator, e.g., `Overlaps`.
+// The condition is rendered as `column + sign + placeholder`,
+// where process returns the placeholder and its args for the field value,
+// and isValid decides whether to apply the condition, nil for always.
+// It should be called at initialization before any query is built.
+func RegisterOperator(name, sign string, process func(value reflect.Value) (string, []any), isValid func(value reflect.Value) bool) {
+	if isValid == nil {
+		isValid = ok
+	}
+	opMap[name] = operator{name, sign, process, isValid}
+	RegisterSuffix(name)
+	subOfRgx, fieldRgx = buildSubOfRgx(), buildFieldRgx()
+}
+
 func resolvePlaceHolder(arg string) string {
 	ph := "?"
 	if strings.Contains(arg, "\\") {
@@ -145,24 +197,31 @@ func resolvePlaceHolder(arg string) string {
 }
 
 type fpSuffix struct {
-	col string
-	op  operator
+	col        string
+	op         operator
+	ignoreCase bool
 }
 
 func buildFpSuffix(fieldName string) fpSuffix {
 	if match := SuffixRgx.FindStringSubmatch(fieldName); len(match) > 0 {
 		op := opMap[match[1]]
-		column := strings.TrimSuffix(fieldName, match[1])
+		column := strings.TrimSuffix(fieldName, match[0])
 		column = ConvertToColumnCase(column)
-		return fpSuffix{column, op}
+		return fpSuffix{column, op, match[2] != ""}
 	}
-	return fpSuffix{ConvertToColumnCase(fieldName), opMap["Eq"]}
+	return fpSuffix{ConvertToColumnCase(fieldName), opMap["Eq"], false}
 }
 
-func (fp fpSuffix) Process(value reflect.Value) (string, []any) {
+func (fp fpSuffix) Process(s *scope, value reflect.Value) (string, []any) {
 	if !fp.op.isValid(value) {
 		return "", []any{}
 	}
 	placeholder, args := fp.op.process(value)
+	if s.options.QueryDialect.TimeAsText() {
+		args = toUTC(args)
+	}
+	if fp.ignoreCase {
+		return s.options.QueryDialect.IgnoreCase(fp.col, fp.op.sign, placeholder), args
+	}
 	return fp.col + fp.op.sign + placeholder, args
 }
@@ -11,7 +11,9 @@
 package rdb
 
 import (
+	"context"
 	"fmt"
+	. "github.com/doytowin/goooqo/core"
 	"reflect"
 	"testing"
 )
@@ -23,6 +25,7 @@ type mapping struct {
 }
 
 func TestProcess(t *testing.T) {
+	five, nine := 5, 9
 	useCases := []mapping{
 		{"id", "id = ?", []int64{5}, reflect.ValueOf(5)},
 		{"idGt", "id > ?", []int64{5}, reflect.ValueOf(5)},
@@ -48,12 +51,23 @@ func TestProcess(t *testing.T) {
 		{"memoNull", "memo IS NULL", nil, reflect.ValueOf(true)},
 		{"memoNull", "memo IS NOT NULL", nil, reflect.ValueOf(false)},
 
+		{"idBetween", "id BETWEEN ? AND ?", []int{5, 9}, reflect.ValueOf(Range[int]{From: &five, To: &nine})},
+		{"idBetween", "id >= ?", []int{5}, reflect.ValueOf(Range[int]{From: &five})},
+		{"idBetween", "id <= ?", []int{9}, reflect.ValueOf(Range[int]{To: &nine})},
+		{"idBetween", "", nil, reflect.ValueOf(Range[int]{})},
+
+		{"memoEqIgnoreCase", "LOWER(memo) = LOWER(?)", "[Good]", reflect.ValueOf("Good")},
+		{"memoContainIgnoreCase", "LOWER(memo) LIKE LOWER(?)", "[%at%]", reflect.ValueOf("at")},
+		{"memoStartIgnoreCase", "LOWER(memo) LIKE LOWER(?) ESCAPE '\\'", "[a\\_t%]", reflect.ValueOf("a_t")},
+		{"memoNotEndIgnoreCase", "LOWER(memo) NOT LIKE LOWER(?)", "[%at]", reflect.ValueOf("at")},
+		{"memoInIgnoreCase", "LOWER(memo) IN (LOWER(?), LOWER(?))", "[Good Bad]", reflect.ValueOf([]string{"Good", "Bad"})},
+
 		{"idIn", "", []int{}, reflect.ValueOf([]int{})},
 		{"idNotIn", "", []int{}, reflect.ValueOf([]int{})},
 	}
 	for _, useCase := range useCases {
 		t.Run(useCase.field, func(t *testing.T) {
-			actual, arg := buildFpSuffix(useCase.field).Process(useCase.value)
+			actual, arg := buildFpSuffix(useCase.field).Process(staticScope(), useCase.value)
 			if actual != useCase.expect {
 				t.Errorf("Expected: %s, but got %s", useCase.expect, actual)
 			}
@@ -62,5 +76,50 @@ func TestProcess(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestProcessIgnoreCaseForPostgres(t *testing.T) {
+	s := newScope(context.Background(), BuildOptions(WithQueryDialect(PostgresQueryDialect)))
 
+	useCases := []mapping{
+		{"memoEqIgnoreCase", "LOWER(memo) = LOWER(?)", "[Good]", reflect.ValueOf("Good")},
+		{"memoContainIgnoreCase", "memo ILIKE ?", "[%at%]", reflect.ValueOf("at")},
+		{"memoNotStartIgnoreCase", "memo NOT ILIKE ?", "[at%]", reflect.ValueOf("at")},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.field, func(t *testing.T) {
+			actual, arg := buildFpSuffix(useCase.field).Process(s, useCase.value)
+			if actual != useCase.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expect, actual)
+			}
+			if fmt.Sprint(arg) != fmt.Sprint(useCase.expectValue) {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expectValue, arg)
+			}
+		})
+	}
+}
+
+func TestRegisterOperator(t *testing.T) {
+	RegisterOperator("Mod", " % ", func(value reflect.Value) (string, []any) {
+		return "? = 0", []any{ReadValue(value)}
+	}, func(value reflect.Value) bool {
+		return value.Int() > 0
+	})
+
+	useCases := []mapping{
+		{"scoreMod", "score % ? = 0", "[5]", reflect.ValueOf(5)},
+		{"scoreMod", "", "[]", reflect.ValueOf(0)},
+		{"scoreModAvg", "score % ? = 0", "[5]", reflect.ValueOf(5)},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.field, func(t *testing.T) {
+			actual, arg := buildFpSuffix(trimFieldName(useCase.field)).Process(staticScope(), useCase.value)
+			if actual != useCase.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expect, actual)
+			}
+			if fmt.Sprint(arg) != fmt.Sprint(useCase.expectValue) {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expectValue, arg)
+			}
+		})
+	}
 }
@@ -3,11 +3,10 @@ module github.com/doytowin/goooqo/rdb
 go 1.18
 
 require (
+	github.com/go-sql-driver/mysql v1.7.1
 	github.com/joho/godotenv v1.5.1
+	github.com/mattn/go-sqlite3 v1.14.23
 	github.com/sirupsen/logrus v1.9.3
 )
 
-require (
-	github.com/mattn/go-sqlite3 v1.14.23 // indirect
-	golang.org/x/sys v0.23.0 // indirect
-)
\ No newline at end of file
+require golang.org/x/sys v0.23.0 // indirect
@@ -0,0 +1,139 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"errors"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"testing"
+)
+
+type HookedUserEntity struct {
+	Int64Id
+	Score *int
+	Memo  *string
+}
+
+func (e HookedUserEntity) GetTableName() string {
+	return "t_user"
+}
+
+func (e *HookedUserEntity) BeforeCreate(context.Context) error {
+	if e.Memo == nil {
+		e.Memo = P("Created")
+	}
+	return nil
+}
+
+func (e *HookedUserEntity) AfterCreate(context.Context) error {
+	if *e.Score < 0 {
+		return errors.New("invalid score")
+	}
+	return nil
+}
+
+func (e *HookedUserEntity) BeforeUpdate(context.Context) error {
+	if e.Score != nil && *e.Score > 100 {
+		return errors.New("score exceeded")
+	}
+	return nil
+}
+
+func (e *HookedUserEntity) BeforeDelete(context.Context) error {
+	if *e.Score > 80 {
+		return errors.New("forbidden to delete user with score > 80")
+	}
+	return nil
+}
+
+func (e *HookedUserEntity) AfterQuery(context.Context) error {
+	if e.Memo == nil {
+		e.Memo = P("-")
+	}
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	ctx := context.Background()
+	tm := NewTransactionManager(db)
+
+	userDataAccess := NewTxDataAccess[HookedUserEntity](tm)
+
+	t.Run("Call BeforeCreate before insert", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		entity := HookedUserEntity{Score: P(90)}
+		_, err := userDataAccess.Create(tc, &entity)
+		user, _ := userDataAccess.Get(tc, entity.Id)
+		if !(err == nil && *user.Memo == "Created") {
+			t.Errorf("Unexpected: %v, %v", err, user)
+		}
+	})
+
+	t.Run("Rollback when AfterCreate returns error", func(t *testing.T) {
+		entity := HookedUserEntity{Score: P(-1)}
+		_, err := userDataAccess.Create(ctx, &entity)
+		cnt, _ := userDataAccess.Count(ctx, UserQuery{})
+		if !(err != nil && cnt == 4) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Rollback when AfterCreate returns error in suspended transaction", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		nc, _ := tm.StartTransaction(tc, WithPropagation(PropagationNotSupported))
+		entity := HookedUserEntity{Score: P(-1)}
+		_, err := userDataAccess.Create(nc, &entity)
+		cnt, _ := userDataAccess.Count(nc, UserQuery{})
+		if !(err != nil && cnt == 4) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Abort when BeforeUpdate returns error", func(t *testing.T) {
+		entity := HookedUserEntity{Int64Id: NewInt64Id(2), Score: P(120)}
+		cnt, err := userDataAccess.Patch(ctx, entity)
+		user, _ := userDataAccess.Get(ctx, 2)
+		if !(err != nil && cnt == 0 && *user.Score == 40) {
+			t.Errorf("Unexpected: %v, %d, %v", err, cnt, user)
+		}
+	})
+
+	t.Run("Abort when BeforeDelete returns error", func(t *testing.T) {
+		cnt, err := userDataAccess.DeleteByQuery(ctx, UserQuery{ScoreLt: P(90)})
+		total, _ := userDataAccess.Count(ctx, UserQuery{})
+		if !(err != nil && cnt == 0 && total == 4) {
+			t.Errorf("Unexpected: %v, %d, %d", err, cnt, total)
+		}
+	})
+
+	t.Run("Call BeforeDelete for loaded entities", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		cnt, err := userDataAccess.Delete(tc, 2)
+		if !(err == nil && cnt == 1) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Call AfterQuery for each entity", func(t *testing.T) {
+		users, err := userDataAccess.Query(ctx, UserQuery{MemoNull: P(true)})
+		if !(err == nil && len(users) == 1 && *users[0].Memo == "-") {
+			t.Errorf("Unexpected: %v, %v", err, users)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	. "github.com/doytowin/goooqo/core"
+	"sort"
+	"time"
+)
+
+type Migration struct {
+	Version    int64
+	Name       string
+	Statements []string
+}
+
+// Migrator applies the migrations in the order of version,
+// recording the applied versions in the table `schema_migrations`.
+type Migrator struct {
+	tm      TransactionManager
+	table   string
+	dialect QueryDialect
+}
+
+// NewMigrator creates a Migrator writing the applied versions
+// by the placeholders of the QueryDialect in opts.
+func NewMigrator(db *sql.DB, opts ...Option) *Migrator {
+	options := BuildOptions(opts...)
+	return &Migrator{tm: NewTransactionManager(db, opts...), table: "schema_migrations", dialect: options.QueryDialect}
+}
+
+func (m *Migrator) db() *sql.DB {
+	return m.tm.GetClient().(*sql.DB)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db().ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+m.table+
+		" (version BIGINT PRIMARY KEY, name VARCHAR(255), applied_at TIMESTAMP)")
+	return err
+}
+
+// Applied returns the applied versions in ascending order.
+func (m *Migrator) Applied(ctx context.Context) ([]int64, error) {
+	if err := m.ensureTable(ctx); HasError(err) {
+		return nil, err
+	}
+	rows, err := m.db().QueryContext(ctx, "SELECT version FROM "+m.table+" ORDER BY version")
+	if HasError(err) {
+		return nil, err
+	}
+	defer Close(rows)
+	var versions []int64
+	for rows.Next() {
+		var version int64
+		if err = rows.Scan(&version); HasError(err) {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// Migrate applies the pending migrations, each in its own transaction,
+// and returns the versions applied in this call.
+func (m *Migrator) Migrate(ctx context.Context, migrations ...Migration) ([]int64, error) {
+	versions, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+	sorted := append([]Migration{}, migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	var done []int64
+	for _, migration := range sorted {
+		if applied[migration.Version] {
+			continue
+		}
+		err = m.tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			return m.apply(tc, migration)
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, migration.Version)
+	}
+	return done, nil
+}
+
+func (m *Migrator) apply(tc TransactionContext, migration Migration) error {
+	rtc, _ := getTransaction(tc)
+	tx := rtc.tx
+	for _, statement := range migration.Statements {
+		logSqlWithArgs(statement, nil)
+		if _, err := tx.ExecContext(tc, statement); HasError(err) {
+			return err
+		}
+	}
+	d := m.dialect
+	_, err := tx.ExecContext(tc, "INSERT INTO "+m.table+" (version, name, applied_at) VALUES ("+
+		d.Placeholder(1)+", "+d.Placeholder(2)+", "+d.Placeholder(3)+")",
+		migration.Version, migration.Name, time.Now().UTC())
+	return err
+}
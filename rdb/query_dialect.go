@@ -0,0 +1,170 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// QueryDialect renders the database-specific predicates in the queries,
+// e.g., the suffixes ending with `IgnoreCase`, the JSON paths, the full-text
+// search and the date truncation, configured by WithQueryDialect.
+// It also describes the DDL differences for BuildCreateTable and DiffSchema.
+type QueryDialect interface {
+	// IgnoreCase renders the case-insensitive form of `column sign placeholder`.
+	IgnoreCase(column, sign, placeholder string) string
+	// JsonPath renders the text extracted from the JSON column by the path like `$.address.city`.
+	JsonPath(column, path string) string
+	// FullText renders the full-text condition on the columns for the search text.
+	FullText(columns []string) string
+	// FullTextRank renders the ordering by relevance, or "" if unsupported.
+	FullTextRank(columns []string) string
+	// TruncDate renders the date part of the time column.
+	TruncDate(column string) string
+	// TimeAsText reports whether the time is stored as text, which is
+	// written in UTC and read in Config.TimeLocation to stay comparable.
+	TimeAsText() bool
+	// ColumnType returns the column type for the Go type,
+	// or an error if the type is unsupported.
+	ColumnType(fieldType reflect.Type) (string, error)
+	// PrimaryKey returns the definition of the auto increment primary key.
+	PrimaryKey(column string, fieldType reflect.Type) (string, error)
+	// ColumnsSql returns the query for the column names of a table.
+	ColumnsSql() string
+	// Placeholder returns the placeholder for the arg at index starting from 1.
+	Placeholder(index int) string
+}
+
+type queryDialect struct {
+	*schemaDialect
+	ilike      bool
+	jsonPath   func(column string, path string) string
+	fullText   func(columns []string) string
+	rank       func(columns []string) string
+	truncDate  string
+	timeAsText bool
+}
+
+func (d *queryDialect) IgnoreCase(column, sign, placeholder string) string {
+	if d.ilike && strings.HasSuffix(sign, "LIKE ") {
+		return column + strings.TrimSuffix(sign, "LIKE ") + "ILIKE " + placeholder
+	}
+	return "LOWER(" + column + ")" + sign + strings.ReplaceAll(placeholder, "?", "LOWER(?)")
+}
+
+func (d *queryDialect) JsonPath(column, path string) string {
+	return d.jsonPath(column, path)
+}
+
+func (d *queryDialect) FullText(columns []string) string {
+	return d.fullText(columns)
+}
+
+func (d *queryDialect) FullTextRank(columns []string) string {
+	return d.rank(columns)
+}
+
+func (d *queryDialect) TruncDate(column string) string {
+	return fmt.Sprintf(d.truncDate, column)
+}
+
+func (d *queryDialect) TimeAsText() bool {
+	return d.timeAsText
+}
+
+func tsVector(columns []string) string {
+	if len(columns) == 1 {
+		return "to_tsvector(" + columns[0] + ")"
+	}
+	document := make([]string, len(columns))
+	for i, column := range columns {
+		document[i] = "coalesce(" + column + ", '')"
+	}
+	return "to_tsvector(" + strings.Join(document, " || ' ' || ") + ")"
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// arrowPath converts `$.address.city` to `->'address'->>'city'`.
+func arrowPath(column string, path string) string {
+	keys := strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "$"), "."), ".")
+	for i, key := range keys {
+		if i < len(keys)-1 {
+			column += "->" + quote(key)
+		} else {
+			column += "->>" + quote(key)
+		}
+	}
+	return column
+}
+
+var SQLiteQueryDialect QueryDialect = &queryDialect{
+	jsonPath: func(column string, path string) string {
+		return "json_extract(" + column + ", " + quote(path) + ")"
+	},
+	// the column can be the name of the FTS table for all its columns
+	fullText: func(columns []string) string {
+		conditions := make([]string, len(columns))
+		for i, column := range columns {
+			conditions[i] = column + " MATCH ?"
+		}
+		if len(conditions) == 1 {
+			return conditions[0]
+		}
+		return "(" + strings.Join(conditions, " OR ") + ")"
+	},
+	// available for FTS5 only
+	rank: func([]string) string {
+		return "rank"
+	},
+	truncDate:     "date(%s)",
+	timeAsText:    true,
+	schemaDialect: sqliteSchema,
+}
+
+// defaultQueryDialect renders the predicates like SQLiteQueryDialect,
+// but keeps the time unconverted for the options without a QueryDialect.
+var defaultQueryDialect QueryDialect = func() QueryDialect {
+	dialect := *SQLiteQueryDialect.(*queryDialect)
+	dialect.timeAsText = false
+	return &dialect
+}()
+
+var MySQLQueryDialect QueryDialect = &queryDialect{
+	jsonPath: func(column string, path string) string {
+		return column + "->>" + quote(path)
+	},
+	fullText: func(columns []string) string {
+		return "MATCH(" + strings.Join(columns, ", ") + ") AGAINST(?)"
+	},
+	rank: func(columns []string) string {
+		return "MATCH(" + strings.Join(columns, ", ") + ") AGAINST(?) DESC"
+	},
+	truncDate:     "DATE(%s)",
+	schemaDialect: mysqlSchema,
+}
+
+var PostgresQueryDialect QueryDialect = &queryDialect{
+	ilike:    true,
+	jsonPath: arrowPath,
+	fullText: func(columns []string) string {
+		return tsVector(columns) + " @@ plainto_tsquery(?)"
+	},
+	rank: func(columns []string) string {
+		return "ts_rank(" + tsVector(columns) + ", plainto_tsquery(?)) DESC"
+	},
+	truncDate:     "CAST(%s AS DATE)",
+	schemaDialect: postgresSchema,
+}
@@ -0,0 +1,181 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var arrayEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Array wraps a slice as an array literal like `{"a","b"}` for the
+// array columns of Postgres, and a non-slice value as a single element.
+func Array(v any) driver.Valuer {
+	return arrayValue{v}
+}
+
+type arrayValue struct {
+	v any
+}
+
+func (a arrayValue) Value() (driver.Value, error) {
+	rv := reflect.Indirect(reflect.ValueOf(a.v))
+	if rv.Kind() != reflect.Slice {
+		return "{" + quoteArrayElem(rv) + "}", nil
+	}
+	elems := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elems[i] = quoteArrayElem(rv.Index(i))
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+func (a arrayValue) String() string {
+	v, _ := a.Value()
+	return v.(string)
+}
+
+func quoteArrayElem(elem reflect.Value) string {
+	if elem.Kind() == reflect.Interface || elem.Kind() == reflect.Pointer {
+		if elem.IsNil() {
+			return "NULL"
+		}
+		elem = elem.Elem()
+	}
+	return `"` + arrayEscaper.Replace(fmt.Sprint(elem.Interface())) + `"`
+}
+
+// arrayScanner parses the array literal of a column into the slice field.
+type arrayScanner struct {
+	field reflect.Value
+}
+
+func (s arrayScanner) Scan(src any) error {
+	s.field.Set(reflect.Zero(s.field.Type()))
+	var str string
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		str = string(data)
+	case string:
+		str = data
+	default:
+		return fmt.Errorf("unsupported array column value: %T", src)
+	}
+	items, err := parseArray(str)
+	if err != nil {
+		return err
+	}
+
+	sliceType := s.field.Type()
+	if sliceType.Kind() == reflect.Pointer {
+		sliceType = sliceType.Elem()
+	}
+	slice := reflect.MakeSlice(sliceType, len(items), len(items))
+	for i, item := range items {
+		if item != nil {
+			if err = setArrayElem(slice.Index(i), *item); err != nil {
+				return err
+			}
+		}
+	}
+	if s.field.Kind() == reflect.Pointer {
+		ptr := reflect.New(sliceType)
+		ptr.Elem().Set(slice)
+		slice = ptr
+	}
+	s.field.Set(slice)
+	return nil
+}
+
+// parseArray parses `{a,"b c",NULL}` to the items, nil for NULL.
+func parseArray(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("invalid array literal: " + s)
+	}
+	s = s[1 : len(s)-1]
+	items := make([]*string, 0, strings.Count(s, ",")+1)
+	if s == "" {
+		return items, nil
+	}
+	var b strings.Builder
+	inQuotes, quoted := false, false
+	appendItem := func() {
+		item := b.String()
+		if !quoted && item == "NULL" {
+			items = append(items, nil)
+		} else {
+			items = append(items, &item)
+		}
+		b.Reset()
+		quoted = false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inQuotes && c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case c == '"':
+			inQuotes, quoted = !inQuotes, true
+		case !inQuotes && c == ',':
+			appendItem()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	appendItem()
+	return items, nil
+}
+
+func setArrayElem(elem reflect.Value, s string) error {
+	switch elem.Kind() {
+	case reflect.String:
+		elem.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		elem.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		elem.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		elem.SetFloat(v)
+	case reflect.Bool:
+		elem.SetBool(s == "t" || s == "true")
+	default:
+		return fmt.Errorf("unsupported array element: %s", elem.Type())
+	}
+	return nil
+}
+
+// RegisterArrayOperators registers the suffixes `Contains`, `Overlap` and `AnyEq`
+// for the array columns, which are opted in since they change the columns
+// of the existing fields ending with them, e.g., `NameContains`.
+func RegisterArrayOperators() {
+	RegisterOperator("Contains", " @> ", BuildArgsForArray, checkValueForIn)
+	RegisterOperator("Overlap", " && ", BuildArgsForArray, checkValueForIn)
+	RegisterOperator("AnyEq", " @> ", BuildArgsForArray, nil)
+}
@@ -0,0 +1,104 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"fmt"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"testing"
+)
+
+type ArticleEntity struct {
+	Int64Id
+	Tags   []string `type:"text[]"`
+	Scores *[]int   `type:"integer[]"`
+}
+
+func (e ArticleEntity) GetTableName() string {
+	return "t_article"
+}
+
+type ArticleQuery struct {
+	PageQuery
+}
+
+func TestArrayColumn(t *testing.T) {
+	t.Run("Parse array literal", func(t *testing.T) {
+		tests := []struct {
+			input, expect string
+		}{
+			{`{}`, `[]`},
+			{`{go,sql}`, `[go sql]`},
+			{`{"a,b","c \"d\"",NULL,"NULL"}`, `[a,b c "d" <nil> NULL]`},
+		}
+		for _, tt := range tests {
+			items, err := parseArray(tt.input)
+			actual := make([]any, len(items))
+			for i, item := range items {
+				if item != nil {
+					actual[i] = *item
+				}
+			}
+			if err != nil || fmt.Sprint(actual) != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %v, %v", tt.expect, actual, err)
+			}
+		}
+	})
+
+	t.Run("Format array literal", func(t *testing.T) {
+		actual, _ := Array([]any{"a,b", `c "d"`, nil, 3}).Value()
+		expect := `{"a,b","c \"d\"",NULL,"3"}`
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Build conditions for array operators", func(t *testing.T) {
+		RegisterArrayOperators()
+		useCases := []mapping{
+			{"tagsContains", "tags @> ?", `[{"go","sql"}]`, reflect.ValueOf([]string{"go", "sql"})},
+			{"tagsContains", "", nil, reflect.ValueOf([]string{})},
+			{"tagsOverlap", "tags && ?", `[{"1","2"}]`, reflect.ValueOf([]int{1, 2})},
+			{"tagsAnyEq", "tags @> ?", `[{"go"}]`, reflect.ValueOf("go")},
+		}
+		for _, useCase := range useCases {
+			actual, arg := buildFpSuffix(useCase.field).Process(staticScope(), useCase.value)
+			if actual != useCase.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expect, actual)
+			}
+			if !((len(arg) == 0 && useCase.expectValue == nil) || fmt.Sprint(arg) == fmt.Sprint(useCase.expectValue)) {
+				t.Errorf("\nExpected: %s\nBut got : %s", useCase.expectValue, arg)
+			}
+		}
+	})
+
+	t.Run("Save and load array columns", func(t *testing.T) {
+		db := Connect()
+		defer Disconnect(db)
+		_, _ = db.Exec(`drop table if exists t_article;
+create table t_article(id integer constraint article_pk primary key autoincrement, tags text, scores text);`)
+		ctx := context.Background()
+		dataAccess := NewTxDataAccess[ArticleEntity](NewTransactionManager(db))
+
+		_, err := dataAccess.CreateMulti(ctx, []ArticleEntity{
+			{Tags: []string{"go", "sql db"}, Scores: &[]int{1, 2}},
+			{Tags: []string{}},
+		})
+		articles, err2 := dataAccess.Query(ctx, ArticleQuery{})
+		if !(err == nil && err2 == nil && len(articles) == 2 &&
+			fmt.Sprint(articles[0].Tags, *articles[0].Scores) == "[go sql db] [1 2]" &&
+			articles[1].Tags != nil && len(articles[1].Tags) == 0 && articles[1].Scores == nil) {
+			t.Errorf("Unexpected: %v, %v, %v", err, err2, articles)
+		}
+	})
+}
@@ -24,8 +24,10 @@ type Connection interface {
 
 type relationalDataAccess[E Entity] struct {
 	TransactionManager
-	conn Connection
-	em   EntityMetadata[E]
+	conn    Connection
+	em      EntityMetadata[E]
+	hooks   Hooks
+	options *Options
 }
 
 func logSqlWithArgs(sqlStr string, args []any) (string, []any) {
@@ -33,43 +35,79 @@ func logSqlWithArgs(sqlStr string, args []any) (string, []any) {
 	return sqlStr, args
 }
 
-func NewTxDataAccess[E Entity](tm TransactionManager) TxDataAccess[E] {
-	return &relationalDataAccess[E]{
+func NewTxDataAccess[E Entity](tm TransactionManager, interceptors ...Interceptor) TxDataAccess[E] {
+	return Intercept[E](&relationalDataAccess[E]{
 		TransactionManager: tm,
 		conn:               tm.GetClient().(Connection),
 		em:                 buildEntityMetadata[E](),
-	}
+		hooks:              HooksOf[E](),
+		options:            optionsOf(tm),
+	}, interceptors...)
+}
+
+func (da *relationalDataAccess[E]) newScope(ctx context.Context) *scope {
+	return newScope(ctx, da.options)
+}
+
+// CacheScope returns the resolved table, since the cached
+// results depend on the table resolved for ctx.
+func (da *relationalDataAccess[E]) CacheScope(ctx context.Context) string {
+	return da.options.ResolveTable(ctx, da.em.TableName)
 }
 
 // getConn get connection from ctx, wrap the ctx and
 // connection by Connection as return value.
-// ctx could be a TransactionContext with an active tx.
+// ctx could be derived from a TransactionContext with an active tx.
 func (da *relationalDataAccess[E]) getConn(ctx context.Context) Connection {
-	if tc, ok := ctx.(*rdbTransactionContext); ok {
+	if tc, ok := getTransaction(ctx); ok {
 		return tc.tx
 	}
 	return da.conn
 }
 
+// getReadConn routes the read outside a transaction to
+// a replica when the TransactionManager is a ReadRouter.
+func (da *relationalDataAccess[E]) getReadConn(ctx context.Context) Connection {
+	if _, ok := getTransaction(ctx); !ok {
+		if router, ok := da.TransactionManager.(ReadRouter); ok {
+			return router.GetReadClient(ctx)
+		}
+	}
+	return da.getConn(ctx)
+}
+
 func (da *relationalDataAccess[E]) Get(ctx context.Context, id any) (*E, error) {
-	sqlStr := da.em.buildSelectById()
-	rows, err := da.doQuery(ctx, sqlStr, []any{id}, 1)
+	sqlStr, args, err := da.em.withTenantById(ctx, da.em.buildSelectById(da.newScope(ctx)), []any{id})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := da.doQuery(ctx, da.getReadConn(ctx), sqlStr, args, 1)
 	if len(rows) == 1 {
+		if NoError(err) {
+			err = CallAfterQuery(ctx, &rows[0])
+		}
 		return &rows[0], err
 	}
 	return nil, err
 }
 
 func (da *relationalDataAccess[E]) Query(ctx context.Context, query Query) ([]E, error) {
-	sqlStr, args := da.em.buildSelect(query)
-	entities, err := da.doQuery(ctx, sqlStr, args, query.GetPageSize())
+	s := da.newScope(ctx)
+	sqlStr, args := da.em.buildSelect(s, query)
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	entities, err := da.doQuery(ctx, da.getReadConn(ctx), sqlStr, args, query.GetPageSize())
 	if NoError(err) && len(da.em.relationMetas) > 0 {
-		da.queryRelationEntities(ctx, entities, query)
+		err = da.queryRelationEntities(s, entities, query)
+	}
+	if NoError(err) {
+		err = CallAfterQuery(ctx, Pointers(entities)...)
 	}
 	return entities, err
 }
 
-func (da *relationalDataAccess[E]) doQuery(ctx context.Context, sqlStr string, args []any, size int) ([]E, error) {
+func (da *relationalDataAccess[E]) doQuery(ctx context.Context, conn Connection, sqlStr string, args []any, size int) ([]E, error) {
 	logSqlWithArgs(sqlStr, args)
 
 	result := make([]E, 0, size)
@@ -79,10 +117,10 @@ func (da *relationalDataAccess[E]) doQuery(ctx context.Context, sqlStr string, a
 	columnMetas := da.em.columnMetas
 	pointers := make([]any, len(columnMetas))
 	for i, cm := range columnMetas {
-		pointers[i] = elem.FieldByName(cm.Field.Name).Addr().Interface()
+		pointers[i] = scanTarget(da.options.QueryDialect, cm, elem.FieldByName(cm.Field.Name))
 	}
 
-	stmt, err := da.getConn(ctx).PrepareContext(ctx, sqlStr)
+	stmt, err := conn.PrepareContext(ctx, sqlStr)
 	if NoError(err) {
 		defer Close(stmt)
 		var rows *sql.Rows
@@ -100,7 +138,10 @@ func (da *relationalDataAccess[E]) doQuery(ctx context.Context, sqlStr string, a
 	return result, err
 }
 
-func (da *relationalDataAccess[E]) queryRelationEntities(ctx context.Context, entities []E, query Query) {
+// queryRelationEntities queries the related entities for the fields
+// with the query assigned to the `With` fields in query.
+func (da *relationalDataAccess[E]) queryRelationEntities(s *scope, entities []E, query Query) error {
+	ctx := s.ctx
 	elem := reflect.ValueOf(query)
 	if elem.Kind() == reflect.Ptr {
 		elem = elem.Elem()
@@ -110,27 +151,37 @@ func (da *relationalDataAccess[E]) queryRelationEntities(ctx context.Context, en
 		entityQueryVal := elem.FieldByName(queryName)
 		if !entityQueryVal.IsNil() {
 			ep := fpEntityPath{*rm.EntityPath}
-			sqlStr, args := ep.buildSql(entityQueryVal.Interface().(Query))
+			sqlStr, args := ep.buildSql(s, entityQueryVal.Interface().(Query))
+			if err := s.Err(); err != nil {
+				return err
+			}
 
 			for i, entity := range entities {
-				relatedEntities, err := QueryRelated(ctx, da.getConn(ctx), sqlStr,
+				relatedEntities, err := queryRelated(ctx, da.getReadConn(ctx), da.options.QueryDialect, sqlStr,
 					append([]any{entity.GetId()}, args...), ep.EntityType)
-				if NoError(err) {
-					reflect.ValueOf(&entities[i]).Elem().FieldByName(rm.Field.Name).Set(relatedEntities)
+				if HasError(err) {
+					return err
 				}
+				reflect.ValueOf(&entities[i]).Elem().FieldByName(rm.Field.Name).Set(relatedEntities)
 			}
 		}
 	}
+	return nil
 }
 
+// QueryRelated queries the related entities with the default QueryDialect.
 func QueryRelated(ctx context.Context, conn Connection, sqlStr string, args []any, entityType reflect.Type) (reflect.Value, error) {
+	return queryRelated(ctx, conn, BuildOptions().QueryDialect, sqlStr, args, entityType)
+}
+
+func queryRelated(ctx context.Context, conn Connection, dialect QueryDialect, sqlStr string, args []any, entityType reflect.Type) (reflect.Value, error) {
 	logSqlWithArgs(sqlStr, args)
 
 	entity := reflect.New(entityType).Elem()
 	fmArr := BuildFieldMetas(entityType)
 	pointers := make([]any, len(fmArr))
 	for i, fm := range fmArr {
-		pointers[i] = entity.FieldByName(fm.Field.Name).Addr().Interface()
+		pointers[i] = scanTarget(dialect, fm, entity.FieldByName(fm.Field.Name))
 	}
 
 	stmt, err := conn.PrepareContext(ctx, sqlStr)
@@ -154,9 +205,13 @@ func QueryRelated(ctx context.Context, conn Connection, sqlStr string, args []an
 
 func (da *relationalDataAccess[E]) Count(ctx context.Context, query Query) (int64, error) {
 	var cnt int64
-	sqlStr, args := da.em.buildCount(query)
+	s := da.newScope(ctx)
+	sqlStr, args := da.em.buildCount(s, query)
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
 	logSqlWithArgs(sqlStr, args)
-	stmt, err := da.getConn(ctx).PrepareContext(ctx, sqlStr)
+	stmt, err := da.getReadConn(ctx).PrepareContext(ctx, sqlStr)
 	if NoError(err) {
 		defer Close(stmt)
 		row := stmt.QueryRowContext(ctx, args...)
@@ -175,13 +230,55 @@ func (da *relationalDataAccess[E]) Page(ctx context.Context, query Query) (PageL
 }
 
 func (da *relationalDataAccess[E]) Delete(ctx context.Context, id any) (int64, error) {
-	sqlStr := da.em.buildDeleteById()
-	return parse(da.doUpdate(ctx, sqlStr, []any{id}))
+	s := da.newScope(ctx)
+	sqlStr, args, err := da.em.withTenantById(ctx, da.em.buildDeleteById(s), []any{id})
+	if err != nil {
+		return 0, err
+	}
+	return da.doDelete(ctx, sqlStr, args, func(ctx context.Context) ([]E, error) {
+		selectStr, args, err := da.em.withTenantById(ctx, da.em.buildSelectById(s), []any{id})
+		if err != nil {
+			return nil, err
+		}
+		return da.doQuery(ctx, da.getConn(ctx), selectStr, args, 1)
+	})
 }
 
 func (da *relationalDataAccess[E]) DeleteByQuery(ctx context.Context, query Query) (int64, error) {
-	sqlStr, args := da.em.buildDelete(query)
-	return parse(da.doUpdate(ctx, sqlStr, args))
+	s := da.newScope(ctx)
+	sqlStr, args := da.em.buildDelete(s, query)
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	return da.doDelete(ctx, sqlStr, args, func(ctx context.Context) ([]E, error) {
+		selectStr, args := da.em.buildSelectForDelete(s, query)
+		return da.doQuery(ctx, da.getConn(ctx), selectStr, args, 0)
+	})
+}
+
+// doDelete loads the entities to be deleted for the delete hooks only when required.
+func (da *relationalDataAccess[E]) doDelete(
+	ctx context.Context, sqlStr string, args []any,
+	load func(ctx context.Context) ([]E, error),
+) (int64, error) {
+	if !da.hooks.NeedDeleted() {
+		return parse(da.doUpdate(ctx, sqlStr, args))
+	}
+	return SubmitWithHooks(ctx, da, da.hooks.AfterDelete, func(ctx context.Context) (int64, error) {
+		entities, err := load(ctx)
+		if HasError(err) {
+			return 0, err
+		}
+		ptrs := Pointers(entities)
+		if err = CallBeforeDelete(ctx, ptrs...); err != nil {
+			return 0, err
+		}
+		cnt, err := parse(da.doUpdate(ctx, sqlStr, args))
+		if NoError(err) {
+			err = CallAfterDelete(ctx, ptrs...)
+		}
+		return cnt, err
+	})
 }
 
 func (da *relationalDataAccess[E]) doUpdate(ctx context.Context, sqlStr string, args []any) (sql.Result, error) {
@@ -195,39 +292,93 @@ func (da *relationalDataAccess[E]) doUpdate(ctx context.Context, sqlStr string,
 }
 
 func (da *relationalDataAccess[E]) Create(ctx context.Context, entity *E) (int64, error) {
-	sqlStr, args := da.em.buildCreate(*entity)
-	result, err := da.doUpdate(ctx, sqlStr, args)
-	var id int64
-	if NoError(err) {
-		id, err = result.LastInsertId()
+	if err := da.em.fillTenant(ctx, entity); err != nil {
+		return 0, err
+	}
+	if err := CallBeforeCreate(ctx, entity); err != nil {
+		return 0, err
+	}
+	return SubmitWithHooks(ctx, da, da.hooks.AfterCreate, func(ctx context.Context) (int64, error) {
+		sqlStr, args := da.em.buildCreate(da.newScope(ctx), *entity)
+		result, err := da.doUpdate(ctx, sqlStr, args)
+		var id int64
 		if NoError(err) {
-			err = (*entity).SetId(entity, id)
+			id, err = result.LastInsertId()
+			if NoError(err) {
+				err = (*entity).SetId(entity, id)
+			}
 		}
-	}
-	return id, err
+		if NoError(err) {
+			err = CallAfterCreate(ctx, entity)
+		}
+		return id, err
+	})
 }
 
 func (da *relationalDataAccess[E]) CreateMulti(ctx context.Context, entities []E) (int64, error) {
 	if len(entities) == 0 {
 		return 0, nil
 	}
-	sqlStr, args := da.em.buildCreateMulti(entities)
-	return parse(da.doUpdate(ctx, sqlStr, args))
+	ptrs := Pointers(entities)
+	if err := da.em.fillTenant(ctx, ptrs...); err != nil {
+		return 0, err
+	}
+	if err := CallBeforeCreate(ctx, ptrs...); err != nil {
+		return 0, err
+	}
+	return SubmitWithHooks(ctx, da, da.hooks.AfterCreate, func(ctx context.Context) (int64, error) {
+		sqlStr, args := da.em.buildCreateMulti(da.newScope(ctx), entities)
+		cnt, err := parse(da.doUpdate(ctx, sqlStr, args))
+		if NoError(err) {
+			err = CallAfterCreate(ctx, ptrs...)
+		}
+		return cnt, err
+	})
 }
 
 func (da *relationalDataAccess[E]) Update(ctx context.Context, entity E) (int64, error) {
-	sqlStr, args := da.em.buildUpdate(entity)
-	return parse(da.doUpdate(ctx, sqlStr, args))
+	return da.doUpdateWithHooks(ctx, &entity, func() (string, []any, error) {
+		sqlStr, args := da.em.buildUpdate(da.newScope(ctx), entity)
+		return da.em.withTenantById(ctx, sqlStr, args)
+	})
 }
 
 func (da *relationalDataAccess[E]) Patch(ctx context.Context, entity E) (int64, error) {
-	sqlStr, args := da.em.buildPatchById(entity)
-	return parse(da.doUpdate(ctx, sqlStr, args))
+	return da.doUpdateWithHooks(ctx, &entity, func() (string, []any, error) {
+		sqlStr, args := da.em.buildPatchById(da.newScope(ctx), entity)
+		return da.em.withTenantById(ctx, sqlStr, args)
+	})
 }
 
 func (da *relationalDataAccess[E]) PatchByQuery(ctx context.Context, entity E, query Query) (int64, error) {
-	sqlStr, args := da.em.buildPatchByQuery(entity, query)
-	return parse(da.doUpdate(ctx, sqlStr, args))
+	return da.doUpdateWithHooks(ctx, &entity, func() (string, []any, error) {
+		s := da.newScope(ctx)
+		sqlStr, args := da.em.buildPatchByQuery(s, entity, query)
+		return sqlStr, args, s.Err()
+	})
+}
+
+// doUpdateWithHooks builds the statement after BeforeUpdate
+// to keep the modification made by the hook.
+// The tenant field is filled to prevent moving the entity to another tenant.
+func (da *relationalDataAccess[E]) doUpdateWithHooks(ctx context.Context, entity *E, build func() (string, []any, error)) (int64, error) {
+	if err := da.em.fillTenant(ctx, entity); err != nil {
+		return 0, err
+	}
+	if err := CallBeforeUpdate(ctx, entity); err != nil {
+		return 0, err
+	}
+	return SubmitWithHooks(ctx, da, da.hooks.AfterUpdate, func(ctx context.Context) (int64, error) {
+		sqlStr, args, err := build()
+		if err != nil {
+			return 0, err
+		}
+		cnt, err := parse(da.doUpdate(ctx, sqlStr, args))
+		if NoError(err) {
+			err = CallAfterUpdate(ctx, entity)
+		}
+		return cnt, err
+	})
 }
 
 func parse(result sql.Result, err error) (int64, error) {
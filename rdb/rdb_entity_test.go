@@ -59,6 +59,8 @@ type TestQuery struct {
 	TestOr     *TestCond
 	EmailEndOr *[]string
 	TestsOr    *[]TestCond
+	TestNot    *TestCond
+	TestsNot   *[]TestCond
 	Account    *string `condition:"(username = ? OR email = ?)"`
 	Deleted    *bool
 }
@@ -0,0 +1,83 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	. "github.com/doytowin/goooqo/core"
+	"strings"
+)
+
+type rdbHistoryStore struct {
+	db      *sql.DB
+	table   string
+	options *Options
+}
+
+// NewHistoryStore creates a HistoryStore for the entity E stored in
+// the table `<table>_history` with columns: id (auto increment),
+// entity_id, operation, before_value, after_value, diff, actor and created_at.
+func NewHistoryStore[E Entity](db *sql.DB, opts ...Option) HistoryStore {
+	return &rdbHistoryStore{db: db, table: FormatTableByEntity(*new(E)) + "_history", options: BuildOptions(opts...)}
+}
+
+func (s *rdbHistoryStore) getConn(ctx context.Context) Connection {
+	if tc, ok := getTransaction(ctx); ok {
+		return tc.tx
+	}
+	return s.db
+}
+
+func (s *rdbHistoryStore) Save(ctx context.Context, records ...HistoryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 7*len(records))
+	for _, r := range records {
+		args = append(args, r.EntityId, r.Operation, r.Before, r.After, r.Diff, r.Actor, r.CreatedAt)
+	}
+	sqlStr := "INSERT INTO " + s.options.ResolveTable(ctx, s.table) + " (entity_id, operation, before_value, after_value, diff, actor, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?)" + strings.Repeat(", (?, ?, ?, ?, ?, ?, ?)", len(records)-1)
+	logSqlWithArgs(sqlStr, args)
+	stmt, err := s.getConn(ctx).PrepareContext(ctx, sqlStr)
+	if NoError(err) {
+		defer Close(stmt)
+		_, err = stmt.ExecContext(ctx, args...)
+	}
+	return err
+}
+
+func (s *rdbHistoryStore) History(ctx context.Context, id any) ([]HistoryRecord, error) {
+	sqlStr := "SELECT entity_id, operation, before_value, after_value, diff, actor, created_at FROM " +
+		s.options.ResolveTable(ctx, s.table) + " WHERE entity_id = ? ORDER BY id"
+	logSqlWithArgs(sqlStr, []any{id})
+	stmt, err := s.getConn(ctx).PrepareContext(ctx, sqlStr)
+	if HasError(err) {
+		return nil, err
+	}
+	defer Close(stmt)
+	rows, err := stmt.QueryContext(ctx, id)
+	if HasError(err) {
+		return nil, err
+	}
+	defer Close(rows)
+	var records []HistoryRecord
+	for rows.Next() {
+		r := HistoryRecord{}
+		err = rows.Scan(&r.EntityId, &r.Operation, &r.Before, &r.After, &r.Diff, &r.Actor, &r.CreatedAt)
+		if HasError(err) {
+			return records, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,63 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer InitDB(db)
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_user_history;
+create table t_user_history(id integer primary key autoincrement, entity_id integer, operation varchar(16),
+before_value text, after_value text, diff text, actor varchar(64), created_at datetime);`)
+	ctx := WithActor(context.Background(), "alice")
+	tm := NewTransactionManager(db)
+	userDataAccess := NewAuditedDataAccess(NewTxDataAccess[UserEntity](tm), NewHistoryStore[UserEntity](db))
+
+	entity := UserEntity{Score: P(90), Memo: P("New")}
+	_, _ = userDataAccess.Create(ctx, &entity)
+	_, _ = userDataAccess.Patch(ctx, UserEntity{Int64Id: entity.Int64Id, Score: P(95)})
+	_, _ = userDataAccess.DeleteByQuery(ctx, UserQuery{IdGt: P(4)})
+
+	records, err := userDataAccess.History(context.Background(), entity.Id)
+	if !(err == nil && len(records) == 3) {
+		t.Fatalf("Unexpected: %v, %v", err, records)
+	}
+
+	expects := []struct{ operation, before, after, diff string }{
+		{"Create", "", `{"id":5,"score":90,"memo":"New"}`, `{"id":[null,5],"memo":[null,"New"],"score":[null,90]}`},
+		{"Update", `{"id":5,"score":90,"memo":"New"}`, `{"id":5,"score":95,"memo":"New"}`, `{"score":[90,95]}`},
+		{"Delete", `{"id":5,"score":95,"memo":"New"}`, "", `{"id":[5,null],"memo":["New",null],"score":[95,null]}`},
+	}
+	for i, expect := range expects {
+		r := records[i]
+		if !(r.Operation == expect.operation && r.Before == expect.before && r.After == expect.after &&
+			r.Diff == expect.diff && r.Actor == "alice" && r.EntityId == int64(5)) {
+			t.Errorf("\nExpected: %v\nBut got : %v", expect, r)
+		}
+	}
+
+	_ = tm.SubmitTransaction(context.Background(), func(tc TransactionContext) error {
+		_, err := userDataAccess.Patch(WithActor(tc, "bob"), UserEntity{Int64Id: NewInt64Id(1), Score: P(80)})
+		return err
+	})
+	records, err = userDataAccess.History(context.Background(), int64(1))
+	if !(err == nil && len(records) == 1 && records[0].Actor == "bob" && records[0].Diff == `{"score":[85,80]}`) {
+		t.Errorf("Unexpected: %v, %v", err, records)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"time"
+)
+
+// jsonValue marshals the field of a JSON column on write.
+type jsonValue struct {
+	v any
+}
+
+func (v jsonValue) Value() (driver.Value, error) {
+	data, err := json.Marshal(v.v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (v jsonValue) String() string {
+	data, _ := json.Marshal(v.v)
+	return string(data)
+}
+
+// jsonScanner unmarshals the JSON column into the field on scan.
+type jsonScanner struct {
+	field reflect.Value
+}
+
+func (s jsonScanner) Scan(src any) error {
+	// reset the field since the entity is reused for each row
+	s.field.Set(reflect.Zero(s.field.Type()))
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, s.field.Addr().Interface())
+	case string:
+		return json.Unmarshal([]byte(data), s.field.Addr().Interface())
+	default:
+		return fmt.Errorf("unsupported JSON column value: %T", src)
+	}
+}
+
+func readColumnValue(dialect QueryDialect, fm FieldMetadata, value reflect.Value) any {
+	if dialect.TimeAsText() && isTimeColumn(fm.Field.Type) {
+		if t, ok := ReadValue(value).(time.Time); ok {
+			return t.UTC()
+		}
+		return nil
+	} else if !fm.IsJson && !fm.IsArray {
+		return ReadValue(value)
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+	}
+	if fm.IsArray {
+		return arrayValue{value.Interface()}
+	}
+	return jsonValue{value.Interface()}
+}
+
+func scanTarget(dialect QueryDialect, fm FieldMetadata, field reflect.Value) any {
+	if fm.IsJson {
+		return jsonScanner{field}
+	} else if fm.IsArray {
+		return arrayScanner{field}
+	} else if dialect.TimeAsText() && isTimeColumn(fm.Field.Type) {
+		return timeScanner{field}
+	}
+	return field.Addr().Interface()
+}
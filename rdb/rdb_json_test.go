@@ -0,0 +1,108 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"testing"
+)
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty"`
+}
+
+type ProfileEntity struct {
+	Int64Id
+	Name    *string
+	Address Address           `type:"JSON"`
+	Labels  map[string]string `type:"JSON"`
+}
+
+func (e ProfileEntity) GetTableName() string {
+	return "t_profile"
+}
+
+type ProfileQuery struct {
+	PageQuery
+	Address      *string `json:"$.city"`
+	AddressStart *string `json:"$.street,omitempty"`
+	LabelsNull   *bool   `json:"$.level"`
+}
+
+func TestJsonColumn(t *testing.T) {
+	db := Connect()
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_profile;
+create table t_profile(id integer constraint profile_pk primary key autoincrement, name varchar(32), address json not null, labels json);
+INSERT INTO t_profile(name, address, labels) VALUES ('f1', '{"city":"Paris","street":"Rue 1"}', '{"level":"gold"}'), ('f2', '{"city":"Rome"}', null), ('f3', '{"city":"Paris","street":"Via 2"}', '{}');`)
+	ctx := context.Background()
+	tm := NewTransactionManager(db)
+	dataAccess := NewTxDataAccess[ProfileEntity](tm)
+
+	t.Run("Render JSON path by dialect", func(t *testing.T) {
+		tests := []struct {
+			dialect QueryDialect
+			expect  string
+		}{
+			{SQLiteQueryDialect, "json_extract(address, '$.city') = ?"},
+			{MySQLQueryDialect, "address->>'$.city' = ?"},
+			{PostgresQueryDialect, "address->>'city' = ?"},
+		}
+		for _, tt := range tests {
+			s := newScope(ctx, BuildOptions(WithQueryDialect(tt.dialect)))
+			actual, _ := s.buildConditions(ProfileQuery{Address: P("Paris")}, "", " AND ", "")
+			if actual != tt.expect {
+				t.Errorf("\nExpected: %s\nBut got : %s", tt.expect, actual)
+			}
+		}
+	})
+
+	t.Run("Render nested JSON path for Postgres", func(t *testing.T) {
+		actual := PostgresQueryDialect.JsonPath("address", "$.geo.lat")
+		expect := "address->'geo'->>'lat'"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Unmarshal JSON columns on query", func(t *testing.T) {
+		profiles, err := dataAccess.Query(ctx, ProfileQuery{})
+		if !(err == nil && len(profiles) == 3 &&
+			profiles[0].Address.Street == "Rue 1" && profiles[0].Labels["level"] == "gold" &&
+			profiles[1].Address.Street == "" && profiles[1].Labels == nil &&
+			profiles[2].Address.City == "Paris" && len(profiles[2].Labels) == 0) {
+			t.Errorf("Unexpected: %v, %v", err, profiles)
+		}
+	})
+
+	t.Run("Filter by JSON path", func(t *testing.T) {
+		cnt1, err1 := dataAccess.Count(ctx, ProfileQuery{Address: P("Paris")})
+		cnt2, err2 := dataAccess.Count(ctx, ProfileQuery{AddressStart: P("Via")})
+		cnt3, err3 := dataAccess.Count(ctx, ProfileQuery{LabelsNull: P(false)})
+		if !(err1 == nil && err2 == nil && err3 == nil && cnt1 == 2 && cnt2 == 1 && cnt3 == 1) {
+			t.Errorf("Unexpected: %v, %v, %v, %d, %d, %d", err1, err2, err3, cnt1, cnt2, cnt3)
+		}
+	})
+
+	t.Run("Marshal JSON columns on create and patch", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		entity := ProfileEntity{Name: P("f4"), Address: Address{City: "Oslo"}, Labels: map[string]string{"level": "silver"}}
+		id, err := dataAccess.Create(tc, &entity)
+		_, err2 := dataAccess.Patch(tc, ProfileEntity{Int64Id: NewInt64Id(id), Address: Address{City: "Bergen"}})
+		profile, _ := dataAccess.Get(tc, id)
+		if !(err == nil && err2 == nil && profile.Address.City == "Bergen" && profile.Labels["level"] == "silver") {
+			t.Errorf("Unexpected: %v, %v, %v", err, err2, profile)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import "context"
+
+// Options configures the components created on a database,
+// e.g., the TransactionManager and the DataAccess created with it.
+type Options struct {
+	TableResolver TableResolver
+	// RetryClassifier recognizes the retryable errors of the driver
+	// not recognized by IsRetryable, e.g., the lock wait timeout.
+	RetryClassifier func(err error) bool
+	// QueryDialect renders the database-specific predicates, default to
+	// the predicates of SQLite without converting the time to UTC.
+	QueryDialect QueryDialect
+}
+
+type Option func(*Options)
+
+// WithTableResolver resolves the table names in the statements
+// with the resolver, including the tables in the subqueries.
+func WithTableResolver(resolver TableResolver) Option {
+	return func(o *Options) {
+		o.TableResolver = resolver
+	}
+}
+
+// WithRetryClassifier recognizes the retryable errors of the driver
+// by the classifier, which usually checks the error of the driver
+// by errors.As, in addition to the errors recognized by IsRetryable.
+func WithRetryClassifier(classifier func(err error) bool) Option {
+	return func(o *Options) {
+		o.RetryClassifier = classifier
+	}
+}
+
+// WithQueryDialect renders the database-specific predicates in the queries
+// by the dialect, e.g., PostgresQueryDialect renders `IgnoreCase` by ILIKE.
+func WithQueryDialect(dialect QueryDialect) Option {
+	return func(o *Options) {
+		o.QueryDialect = dialect
+	}
+}
+
+func BuildOptions(opts ...Option) *Options {
+	options := &Options{QueryDialect: defaultQueryDialect}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// ResolveTable resolves the table by the TableResolver,
+// and keeps the table unchanged without a TableResolver.
+func (o *Options) ResolveTable(ctx context.Context, table string) string {
+	if o.TableResolver == nil {
+		return table
+	}
+	return o.TableResolver(ctx, table)
+}
+
+// optionsHolder is implemented by the TransactionManager
+// sharing its Options with the DataAccess created with it.
+type optionsHolder interface {
+	getOptions() *Options
+}
+
+func optionsOf(tm any) *Options {
+	if holder, ok := tm.(optionsHolder); ok {
+		return holder.getOptions()
+	}
+	return BuildOptions()
+}
@@ -0,0 +1,84 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	. "github.com/doytowin/goooqo/core"
+	"strings"
+	"time"
+)
+
+type rdbOutbox struct {
+	db      *sql.DB
+	table   string
+	options *Options
+}
+
+// NewOutbox creates an Outbox stored in the table with columns:
+// id (auto increment), topic, event_key, payload, created_at and sent_at,
+// where the time is written in UTC and loaded in Config.TimeLocation.
+func NewOutbox(db *sql.DB, table string, opts ...Option) Outbox {
+	return &rdbOutbox{db: db, table: table, options: BuildOptions(opts...)}
+}
+
+func (o *rdbOutbox) Append(ctx context.Context, events ...OutboxEvent) error {
+	tc, ok := getTransaction(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 4*len(events))
+	now := time.Now().UTC()
+	for _, event := range events {
+		args = append(args, event.Topic, event.Key, event.Payload, now)
+	}
+	sqlStr := "INSERT INTO " + o.options.ResolveTable(ctx, o.table) + " (topic, event_key, payload, created_at) VALUES (?, ?, ?, ?)" +
+		strings.Repeat(", (?, ?, ?, ?)", len(events)-1)
+	logSqlWithArgs(sqlStr, args)
+	_, err := tc.tx.ExecContext(ctx, sqlStr, args...)
+	return err
+}
+
+func (o *rdbOutbox) Fetch(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	sqlStr := "SELECT id, topic, event_key, payload, created_at FROM " + o.options.ResolveTable(ctx, o.table) +
+		" WHERE sent_at IS NULL ORDER BY id"
+	sqlStr = BuildPageClause(&sqlStr, 0, limit)
+	logSqlWithArgs(sqlStr, nil)
+	rows, err := o.db.QueryContext(ctx, sqlStr)
+	if HasError(err) {
+		return nil, err
+	}
+	defer Close(rows)
+	events := make([]OutboxEvent, 0, limit)
+	for rows.Next() {
+		var id int64
+		event := OutboxEvent{}
+		if err = rows.Scan(&id, &event.Topic, &event.Key, &event.Payload, &event.CreatedAt); HasError(err) {
+			return events, err
+		}
+		event.Id = id
+		event.CreatedAt = event.CreatedAt.In(Config.TimeLocation)
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (o *rdbOutbox) MarkSent(ctx context.Context, id any) error {
+	sqlStr := "UPDATE " + o.options.ResolveTable(ctx, o.table) + " SET sent_at = ? WHERE id = ?"
+	args := []any{time.Now().UTC(), id}
+	logSqlWithArgs(sqlStr, args)
+	_, err := o.db.ExecContext(ctx, sqlStr, args...)
+	return err
+}
@@ -0,0 +1,103 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"errors"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryPublisher struct {
+	events []OutboxEvent
+	failAt int
+}
+
+func (p *memoryPublisher) Publish(_ context.Context, event OutboxEvent) error {
+	if len(p.events)+1 == p.failAt {
+		p.failAt = 0
+		return errors.New("publish failed")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestOutbox(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_outbox;
+create table t_outbox(id integer primary key autoincrement, topic varchar(64), event_key varchar(64), payload blob, created_at datetime, sent_at datetime);`)
+	ctx := context.Background()
+	tm := NewTransactionManager(db)
+	userDataAccess := NewTxDataAccess[UserEntity](tm)
+	outbox := NewOutbox(db, "t_outbox")
+
+	t.Run("Require transaction to append events", func(t *testing.T) {
+		err := outbox.Append(ctx, OutboxEvent{Topic: "user"})
+		if err != ErrNoTransaction {
+			t.Errorf("\nExpected: %s\nBut got : %v", ErrNoTransaction, err)
+		}
+	})
+
+	t.Run("Append events with the transaction", func(t *testing.T) {
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			_, _ = userDataAccess.Delete(tc, 1)
+			return outbox.Append(tc, OutboxEvent{Topic: "user", Key: "1", Payload: []byte("deleted")})
+		})
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			_ = outbox.Append(tc, OutboxEvent{Topic: "user", Key: "2", Payload: []byte("deleted")})
+			return errors.New("rollback")
+		})
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			return outbox.Append(tc,
+				OutboxEvent{Topic: "user", Key: "3", Payload: []byte("created")},
+				OutboxEvent{Topic: "user", Key: "4", Payload: []byte("created")})
+		})
+
+		events, err := outbox.Fetch(ctx, 10)
+		if !(err == nil && len(events) == 3 && events[0].Key == "1" && events[2].Key == "4") {
+			t.Errorf("Unexpected: %v, %v", err, events)
+		}
+	})
+
+	t.Run("Write created_at in UTC", func(t *testing.T) {
+		Config.TimeLocation = time.FixedZone("UTC+8", 8*3600)
+		defer func() { Config.TimeLocation = time.UTC }()
+
+		var createdAt string
+		_ = db.QueryRow("SELECT created_at FROM t_outbox ORDER BY id LIMIT 1").Scan(&createdAt)
+		events, err := outbox.Fetch(ctx, 1)
+		if !(err == nil && strings.HasSuffix(createdAt, "Z") &&
+			len(events) == 1 && events[0].CreatedAt.Location() == Config.TimeLocation) {
+			t.Errorf("Unexpected: %v, %s, %v", err, createdAt, events)
+		}
+	})
+
+	t.Run("Relay events at least once", func(t *testing.T) {
+		publisher := &memoryPublisher{failAt: 2}
+		relay := NewRelay(outbox, publisher, 2, 0)
+
+		cnt1, err1 := relay.RelayOnce(ctx)
+		cnt2, _ := relay.RelayOnce(ctx)
+		cnt3, _ := relay.RelayOnce(ctx)
+
+		if !(err1 != nil && strings.HasPrefix(err1.Error(), "publish outbox event 2 to user") &&
+			cnt1 == 1 && cnt2 == 2 && cnt3 == 0 &&
+			len(publisher.events) == 3 && publisher.events[2].Key == "4") {
+			t.Errorf("Unexpected: %v, %d, %d, %d, %v", err1, cnt1, cnt2, cnt3, publisher.events)
+		}
+	})
+}
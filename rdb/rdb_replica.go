@@ -0,0 +1,71 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	. "github.com/doytowin/goooqo/core"
+	"sync/atomic"
+)
+
+// ReadRouter is implemented by the TransactionManager
+// which routes the reads outside a transaction.
+type ReadRouter interface {
+	GetReadClient(ctx context.Context) Connection
+}
+
+type Replica struct {
+	DB     *sql.DB
+	Weight int
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx forcing the reads to the primary,
+// which is useful to read the data just written.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func IsPrimaryForced(ctx context.Context) bool {
+	return ctx.Value(primaryKey{}) == true
+}
+
+type replicaTransactionManager struct {
+	*rdbTransactionManager
+	schedule []*sql.DB
+	next     uint64
+}
+
+// NewReplicaTransactionManager creates a TransactionManager
+// starting transactions on the primary and routing the reads
+// to the replicas in weighted round-robin order.
+// A replica with a non-positive weight is weighted as 1.
+func NewReplicaTransactionManager(primary *sql.DB, replicas []Replica, opts ...Option) TransactionManager {
+	tm := &replicaTransactionManager{
+		rdbTransactionManager: NewTransactionManager(primary, opts...).(*rdbTransactionManager),
+	}
+	for _, replica := range replicas {
+		for i := 0; i < replica.Weight || i == 0; i++ {
+			tm.schedule = append(tm.schedule, replica.DB)
+		}
+	}
+	return tm
+}
+
+func (t *replicaTransactionManager) GetReadClient(ctx context.Context) Connection {
+	if len(t.schedule) == 0 || IsPrimaryForced(ctx) {
+		return t.db
+	}
+	n := atomic.AddUint64(&t.next, 1) - 1
+	return t.schedule[n%uint64(len(t.schedule))]
+}
@@ -0,0 +1,70 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"testing"
+)
+
+func openReplica(name string, rows int) *sql.DB {
+	db, _ := sql.Open("sqlite3", "file:"+name+"?mode=memory&cache=shared")
+	db.SetMaxIdleConns(1)
+	_, _ = db.Exec("create table t_user(id integer primary key autoincrement, score integer, memo varchar(255))")
+	for i := 0; i < rows; i++ {
+		_, _ = db.Exec("INSERT INTO t_user(score, memo) VALUES (?, ?)", 60+i, name)
+	}
+	return db
+}
+
+func TestReplica(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	replica1, replica2 := openReplica("replica1", 1), openReplica("replica2", 2)
+	defer Disconnect(replica1)
+	defer Disconnect(replica2)
+	ctx := context.Background()
+
+	tm := NewReplicaTransactionManager(db, []Replica{{DB: replica1}, {DB: replica2, Weight: 2}})
+	userDataAccess := NewTxDataAccess[UserEntity](tm)
+
+	t.Run("Route reads to replicas by weight", func(t *testing.T) {
+		var counts []int64
+		for i := 0; i < 3; i++ {
+			cnt, _ := userDataAccess.Count(ctx, UserQuery{})
+			counts = append(counts, cnt)
+		}
+		if !(counts[0] == 1 && counts[1] == 2 && counts[2] == 2) {
+			t.Errorf("Unexpected: %v", counts)
+		}
+	})
+
+	t.Run("Read from primary when forced", func(t *testing.T) {
+		users, err := userDataAccess.Query(WithPrimary(ctx), UserQuery{})
+		if !(err == nil && len(users) == 4) {
+			t.Errorf("Unexpected: %v, %v", err, users)
+		}
+	})
+
+	t.Run("Write to primary and read in tx from primary", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		_, err := userDataAccess.Create(tc, &UserEntity{Score: P(90)})
+		cnt, _ := userDataAccess.Count(tc, UserQuery{})
+		if !(err == nil && cnt == 5) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"errors"
+	"reflect"
+)
+
+// IsRetryable recognizes the serialization failures and deadlocks
+// reported by SQLSTATE 40001/40P01 through the SQLState method,
+// which is implemented by the Postgres drivers pq and pgx,
+// and the errors of the drivers listed in retryableCodes.
+// The errors of other drivers are recognized by the classifier
+// configured by WithRetryClassifier.
+func IsRetryable(err error) bool {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		code := state.SQLState()
+		return code == "40001" || code == "40P01"
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if isRetryableCode(err) {
+			return true
+		}
+	}
+	return false
+}
+
+type errorCode struct {
+	field string
+	codes []uint64
+}
+
+// retryableCodes maps the error types of the drivers to the field of
+// the error code and the retryable codes, which are read by reflection
+// to recognize the errors without depending on the drivers:
+//   - sqlite3.Error of mattn/go-sqlite3: SQLITE_BUSY(5) and SQLITE_LOCKED(6);
+//   - mysql.MySQLError of go-sql-driver/mysql: ER_LOCK_DEADLOCK(1213).
+var retryableCodes = map[string]errorCode{
+	"github.com/mattn/go-sqlite3.Error":         {"Code", []uint64{5, 6}},
+	"github.com/go-sql-driver/mysql.MySQLError": {"Number", []uint64{1213}},
+}
+
+func isRetryableCode(err error) bool {
+	rv := reflect.Indirect(reflect.ValueOf(err))
+	ec, ok := retryableCodes[rv.Type().PkgPath()+"."+rv.Type().Name()]
+	if !ok || rv.Kind() != reflect.Struct {
+		return false
+	}
+	var code uint64
+	switch field := rv.FieldByName(ec.field); field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		code = uint64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		code = field.Uint()
+	default:
+		return false
+	}
+	for _, c := range ec.codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *rdbTransactionManager) IsRetryable(err error) bool {
+	if classifier := t.options.RetryClassifier; classifier != nil && classifier(err) {
+		return true
+	}
+	return IsRetryable(err)
+}
@@ -0,0 +1,102 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"testing"
+)
+
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "pq: " + e.code
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+// busyError mocks the error of a driver without the SQLState method.
+type busyError struct {
+	code int
+}
+
+func (e busyError) Error() string {
+	return "database is locked"
+}
+
+func isBusy(err error) bool {
+	var busy busyError
+	return errors.As(err, &busy) && busy.code == 5
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{"Busy by classifier", busyError{5}, true},
+		{"Wrapped busy by classifier", fmt.Errorf("commit: %w", busyError{5}), true},
+		{"Constraint by classifier", busyError{19}, false},
+		{"Postgres serialization failure", &pgError{"40001"}, true},
+		{"Postgres unique violation", &pgError{"23505"}, false},
+		{"SQLite busy", sqlite3.Error{Code: sqlite3.ErrBusy}, true},
+		{"Wrapped SQLite locked", fmt.Errorf("commit: %w", sqlite3.Error{Code: sqlite3.ErrLocked}), true},
+		{"SQLite constraint", sqlite3.Error{Code: sqlite3.ErrConstraint}, false},
+		{"MySQL deadlock", &mysql.MySQLError{Number: 1213}, true},
+		{"MySQL duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"Rollback error", &RollbackError{Err: errors.New("rollback"), Origin: &pgError{"40P01"}}, true},
+		{"Unknown error", errors.New("unknown"), false},
+	}
+	tm := NewTransactionManager(nil, WithRetryClassifier(isBusy)).(RetryChecker)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tm.IsRetryable(tt.err); actual != tt.expect {
+				t.Errorf("\nExpected: %v\nBut got : %v", tt.expect, actual)
+			}
+		})
+	}
+}
+
+func TestSubmitWithRetry(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	ctx := context.Background()
+	tm := NewTransactionManager(db, WithRetryClassifier(isBusy))
+	userDataAccess := NewTxDataAccess[UserEntity](tm)
+
+	attempts := 0
+	err := SubmitWithRetry(ctx, tm, RetryPolicy{MaxAttempts: 3}, func(tc TransactionContext) error {
+		attempts++
+		_, err := userDataAccess.Delete(tc, attempts)
+		if attempts == 1 {
+			return busyError{5}
+		}
+		return err
+	})
+	defer InitDB(db)
+
+	entities, _ := userDataAccess.Query(ctx, UserQuery{})
+	if !(err == nil && attempts == 2 && len(entities) == 3 && entities[0].Id == 1) {
+		t.Errorf("Unexpected: %v, %d, %v", err, attempts, entities)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"fmt"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"time"
+)
+
+// sqliteTimeLayouts are the layouts of the time stored as text by SQLite.
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func isTimeColumn(fieldType reflect.Type) bool {
+	return fieldType == TimeType || fieldType == reflect.PtrTo(TimeType)
+}
+
+// toUTC converts the time args to UTC to keep the time stored as text comparable.
+func toUTC(args []any) []any {
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			args[i] = t.UTC()
+		}
+	}
+	return args
+}
+
+// timeScanner scans the time column into the field in Config.TimeLocation.
+type timeScanner struct {
+	field reflect.Value
+}
+
+func (s timeScanner) Scan(src any) error {
+	var t time.Time
+	switch data := src.(type) {
+	case nil:
+		s.field.Set(reflect.Zero(s.field.Type()))
+		return nil
+	case time.Time:
+		t = data
+	case []byte:
+		return s.Scan(string(data))
+	case string:
+		parsed, err := parseSqliteTime(data)
+		if err != nil {
+			return err
+		}
+		t = parsed
+	default:
+		return fmt.Errorf("unsupported time column value: %T", src)
+	}
+	t = t.In(Config.TimeLocation)
+	if s.field.Kind() == reflect.Pointer {
+		s.field.Set(reflect.ValueOf(&t))
+	} else {
+		s.field.Set(reflect.ValueOf(t))
+	}
+	return nil
+}
+
+func parseSqliteTime(s string) (time.Time, error) {
+	for _, layout := range sqliteTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time column value: %s", s)
+}
@@ -0,0 +1,149 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type EventEntity struct {
+	Int64Id
+	Name      *string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func (e EventEntity) GetTableName() string {
+	return "t_event"
+}
+
+type EventQuery struct {
+	PageQuery
+	CreatedAtGe        *time.Time
+	CreatedDate        *time.Time
+	CreatedDateBetween *Range[time.Time]
+	UpdatedAtNull      *bool
+}
+
+func TestTimeColumn(t *testing.T) {
+	db := Connect()
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_event;
+create table t_event(id integer constraint event_pk primary key autoincrement, name varchar(32), created_at datetime not null, updated_at datetime);
+INSERT INTO t_event(name, created_at, updated_at) VALUES ('e1', '2024-01-01 08:30:00+00:00', null), ('e2', '2024-01-02 23:00:00+00:00', '2024-01-03 01:00:00+00:00'), ('e3', '2024-01-05 10:00:00', null);`)
+	ctx := context.Background()
+	tm := NewTransactionManager(db, WithQueryDialect(SQLiteQueryDialect))
+	dataAccess := NewTxDataAccess[EventEntity](tm)
+	day := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+
+	t.Run("Render date truncation by dialect", func(t *testing.T) {
+		tests := []struct {
+			dialect QueryDialect
+			expect  string
+		}{
+			{defaultQueryDialect, "date(created_at) = ?"},
+			{MySQLQueryDialect, "DATE(created_at) = ?"},
+			{PostgresQueryDialect, "CAST(created_at AS DATE) = ?"},
+		}
+		for _, tt := range tests {
+			s := newScope(ctx, BuildOptions(WithQueryDialect(tt.dialect)))
+			actual, args := s.buildConditions(EventQuery{CreatedDate: day("2024-01-01")}, "", " AND ", "")
+			if actual != tt.expect || args[0] != "2024-01-01" {
+				t.Errorf("\nExpected: %s\nBut got : %s %v", tt.expect, actual, args)
+			}
+		}
+	})
+
+	t.Run("Filter by date", func(t *testing.T) {
+		cnt1, err1 := dataAccess.Count(ctx, EventQuery{CreatedDate: day("2024-01-02")})
+		cnt2, err2 := dataAccess.Count(ctx, EventQuery{CreatedDateBetween: &Range[time.Time]{From: day("2024-01-01"), To: day("2024-01-02")}})
+		cnt3, err3 := dataAccess.Count(ctx, EventQuery{CreatedAtGe: day("2024-01-02")})
+		if !(err1 == nil && err2 == nil && err3 == nil && cnt1 == 1 && cnt2 == 2 && cnt3 == 2) {
+			t.Errorf("Unexpected: %v, %v, %v, %d, %d, %d", err1, err2, err3, cnt1, cnt2, cnt3)
+		}
+	})
+
+	t.Run("Filter by date in configured location for time stored as text", func(t *testing.T) {
+		loc := time.FixedZone("UTC+8", 8*3600)
+		Config.TimeLocation = loc
+		defer func() { Config.TimeLocation = time.UTC }()
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+
+		_, _ = dataAccess.Create(tc, &EventEntity{Name: P("e4"), CreatedAt: time.Date(2024, 1, 2, 4, 0, 0, 0, loc)})
+		from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 1, 2, 0, 0, 0, 0, loc)
+		cnt1, err1 := dataAccess.Count(tc, EventQuery{CreatedDate: &to})
+		cnt2, err2 := dataAccess.Count(tc, EventQuery{CreatedDateBetween: &Range[time.Time]{From: &from, To: &to}})
+		if !(err1 == nil && err2 == nil && cnt1 == 1 && cnt2 == 2) {
+			t.Errorf("Unexpected: %v, %v, %d, %d", err1, err2, cnt1, cnt2)
+		}
+	})
+
+	t.Run("Scan time text in configured location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+8", 8*3600)
+		Config.TimeLocation = loc
+		defer func() { Config.TimeLocation = time.UTC }()
+
+		events, err := dataAccess.Query(ctx, EventQuery{})
+		if !(err == nil && len(events) == 3 &&
+			events[0].CreatedAt.Location() == loc && events[0].CreatedAt.Hour() == 16 &&
+			events[0].UpdatedAt == nil && events[1].UpdatedAt.Day() == 3 &&
+			events[2].CreatedAt.Equal(time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC))) {
+			t.Errorf("Unexpected: %v, %v", err, events)
+		}
+	})
+
+	t.Run("Write time in UTC", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		createdAt := time.Date(2024, 2, 1, 2, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+		entity := EventEntity{Name: P("e4"), CreatedAt: createdAt}
+		id, err := dataAccess.Create(tc, &entity)
+		cnt, err2 := dataAccess.Count(tc, EventQuery{CreatedDate: day("2024-01-31")})
+		event, _ := dataAccess.Get(tc, id)
+		if !(err == nil && err2 == nil && cnt == 1 && event.CreatedAt.Equal(createdAt) && event.UpdatedAt == nil) {
+			t.Errorf("Unexpected: %v, %v, %d, %v", err, err2, cnt, event)
+		}
+	})
+	t.Run("Format date in configured location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+8", 8*3600)
+		Config.TimeLocation = loc
+		defer func() { Config.TimeLocation = time.UTC }()
+
+		date := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+		_, args := BuildConditions(EventQuery{CreatedDate: &date}, "", " AND ", "")
+		if args[0] != "2024-01-01" {
+			t.Errorf("\nExpected: %s\nBut got : %v", "2024-01-01", args[0])
+		}
+	})
+
+	t.Run("Keep time for dialect storing time natively", func(t *testing.T) {
+		s := newScope(ctx, BuildOptions(WithQueryDialect(MySQLQueryDialect)))
+		createdAt := time.Date(2024, 2, 1, 2, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+		_, args := s.buildConditions(EventQuery{CreatedAtGe: &createdAt}, "", " AND ", "")
+
+		em := buildEntityMetadata[EventEntity]()
+		_, createArgs := em.buildCreate(s, EventEntity{CreatedAt: createdAt})
+
+		entity := EventEntity{}
+		target := scanTarget(MySQLQueryDialect, em.columnMetas[2], reflect.ValueOf(&entity).Elem().Field(2))
+		if !(args[0] == createdAt && createArgs[1] == createdAt && target == &entity.CreatedAt) {
+			t.Errorf("Unexpected: %v, %v, %v", args, createArgs, target)
+		}
+	})
+}
@@ -15,29 +15,46 @@ import (
 	"database/sql"
 	. "github.com/doytowin/goooqo/core"
 	log "github.com/sirupsen/logrus"
+	"strconv"
 	"sync/atomic"
 )
 
 type rdbTransactionManager struct {
-	db *sql.DB
-	sn *atomic.Value
+	db      *sql.DB
+	sn      *atomic.Value
+	options *Options
 }
 
-func NewTransactionManager(db *sql.DB) TransactionManager {
+func NewTransactionManager(db *sql.DB, opts ...Option) TransactionManager {
 	sn := &atomic.Value{}
 	sn.Store(int64(0))
-	return &rdbTransactionManager{db: db, sn: sn}
+	return &rdbTransactionManager{db: db, sn: sn, options: BuildOptions(opts...)}
 }
 
 func (t *rdbTransactionManager) GetClient() any {
 	return t.db
 }
 
-func (t *rdbTransactionManager) StartTransaction(ctx context.Context) (TransactionContext, error) {
-	if tc, ok := ctx.(*rdbTransactionContext); ok {
-		return tc, nil
+func (t *rdbTransactionManager) getOptions() *Options {
+	return t.options
+}
+
+func (t *rdbTransactionManager) StartTransaction(ctx context.Context, opts ...TxOption) (TransactionContext, error) {
+	options := BuildTxOptions(opts...)
+	tc, inTx := getTransaction(ctx)
+	switch options.Propagation {
+	case PropagationNotSupported:
+		return &NonTransactionContext{Context: SuspendTransaction(ctx)}, nil
+	case PropagationRequired:
+		if inTx {
+			return tc, nil
+		}
+	case PropagationNested:
+		if inTx {
+			return t.startNested(ctx, tc)
+		}
 	}
-	tx, err := t.db.BeginTx(ctx, nil)
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
 	if HasError(err) {
 		return nil, err
 	}
@@ -46,14 +63,37 @@ func (t *rdbTransactionManager) StartTransaction(ctx context.Context) (Transacti
 	return &rdbTransactionContext{Context: ctx, tx: tx, sn: sn}, nil
 }
 
-func (t *rdbTransactionManager) SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error) error {
-	tc, err := t.StartTransaction(ctx)
+// startNested creates a save point in tc and returns a nested
+// TransactionContext which releases or rolls back to the save point.
+// ctx is kept as the parent, which could be derived from tc.
+func (t *rdbTransactionManager) startNested(ctx context.Context, tc *rdbTransactionContext) (TransactionContext, error) {
+	sn := t.fetchSn()
+	savePoint := "nested_" + strconv.FormatInt(sn, 10)
+	if err := tc.SavePoint(savePoint); HasError(err) {
+		return nil, err
+	}
+	log.Debug("Start nested Tx: ", sn)
+	return &rdbTransactionContext{Context: ctx, tx: tc.tx, sn: sn, savePoint: savePoint, outer: tc}, nil
+}
+
+// SubmitTransaction commits the transaction only when it is started here.
+// A joined call runs in an automatic save point instead,
+// so that an error in it only rolls back its own changes.
+func (t *rdbTransactionManager) SubmitTransaction(ctx context.Context, callback func(tc TransactionContext) error, opts ...TxOption) error {
+	if _, ok := getTransaction(ctx); ok && BuildTxOptions(opts...).Propagation == PropagationRequired {
+		opts = append(opts, WithPropagation(PropagationNested))
+	}
+	tc, err := t.StartTransaction(ctx, opts...)
 	if NoError(err) {
 		err = TransactionCallback(tc, callback)
 	}
 	return err
 }
 
+func (t *rdbTransactionManager) Supports(capability Capability) bool {
+	return capability == CapabilitySavePoint || capability == CapabilityNested
+}
+
 func (t *rdbTransactionManager) fetchSn() int64 {
 	var val = t.sn.Load().(int64)
 	for !t.sn.CompareAndSwap(val, val+1) {
@@ -64,18 +104,61 @@ func (t *rdbTransactionManager) fetchSn() int64 {
 
 type rdbTransactionContext struct {
 	context.Context
-	tx *sql.Tx
-	sn int64
+	TxCallbacks
+	tx        *sql.Tx
+	sn        int64
+	savePoint string
+	outer     *rdbTransactionContext
 }
 
+// Commit releases the save point for a nested transaction
+// and hands over the callbacks to the outer transaction.
 func (t *rdbTransactionContext) Commit() error {
 	log.Debug("Commit Tx: ", t.sn)
-	return t.tx.Commit()
+	if t.savePoint != "" {
+		_, err := t.tx.ExecContext(t.Context, "RELEASE SAVEPOINT "+t.savePoint)
+		if err == nil {
+			t.MergeInto(&t.outer.TxCallbacks)
+		}
+		return err
+	}
+	err := t.tx.Commit()
+	t.Complete(err == nil)
+	return err
 }
 
+// Rollback rolls back to the save point and releases it
+// for a nested transaction, since a rolled back save point is kept.
 func (t *rdbTransactionContext) Rollback() error {
 	log.Debug("Rollback Tx: ", t.sn)
-	return t.tx.Rollback()
+	var err error
+	if t.savePoint != "" {
+		err = t.RollbackTo(t.savePoint)
+		if err == nil {
+			_, err = t.tx.ExecContext(t.Context, "RELEASE SAVEPOINT "+t.savePoint)
+		}
+	} else {
+		err = t.tx.Rollback()
+	}
+	if err != sql.ErrTxDone {
+		t.Complete(false)
+	}
+	return err
+}
+
+// getTransaction returns the rdbTransactionContext of ctx,
+// which could also be derived from it, e.g., by WithTenant.
+func getTransaction(ctx context.Context) (*rdbTransactionContext, bool) {
+	tc, _ := GetTransaction(ctx)
+	rtc, ok := tc.(*rdbTransactionContext)
+	return rtc, ok
+}
+
+func (t *rdbTransactionContext) Value(key any) any {
+	if key == TransactionKey {
+		return t
+	}
+	return t.Context.Value(key)
 }
 
 func (t *rdbTransactionContext) Parent() context.Context {
@@ -12,6 +12,9 @@ package rdb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	. "github.com/doytowin/goooqo/core"
 	. "github.com/doytowin/goooqo/test"
 	"testing"
@@ -61,4 +64,168 @@ func TestTx(t *testing.T) {
 			t.Error("Should support SavePoint: ", entities)
 		}
 	})
+
+	t.Run("Support nested propagation by save point", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+
+		_, _ = userDataAccess.Delete(tc, 1)
+		savePoint := ""
+		err := tm.SubmitTransaction(tc, func(tc TransactionContext) error {
+			savePoint = tc.(*rdbTransactionContext).savePoint
+			_, _ = userDataAccess.Delete(tc, 2)
+			return errors.New("rollback nested")
+		}, WithPropagation(PropagationNested))
+		cnt, _ := userDataAccess.Count(tc, UserQuery{})
+		released := tc.RollbackTo(savePoint) != nil
+		if !(err != nil && cnt == 3 && released) {
+			t.Errorf("Unexpected: %v, %d, %v", err, cnt, released)
+		}
+	})
+
+	t.Run("Rollback writes through context derived from transaction", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		_, _ = userDataAccess.Delete(WithActor(tc, "alice"), 1)
+		_ = tc.Rollback()
+
+		cnt, _ := userDataAccess.Count(ctx, UserQuery{})
+		if cnt != 4 {
+			t.Errorf("\nExpected: %d\nBut got : %d", 4, cnt)
+		}
+	})
+
+	t.Run("Start new transaction for requires new propagation", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		tc2, err := tm.StartTransaction(tc, WithPropagation(PropagationRequiresNew))
+		defer tc2.Rollback()
+
+		if !(err == nil && tc2 != tc && tc2.Parent() == tc) {
+			t.Errorf("Should start new transaction: %v", err)
+		}
+	})
+
+	t.Run("Suspend transaction for not supported propagation", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		tc2, _ := tm.StartTransaction(tc, WithPropagation(PropagationNotSupported))
+
+		if _, ok := tc2.(*NonTransactionContext); !(ok && tc2.Parent() == ctx) {
+			t.Errorf("Should suspend transaction: %v", tc2)
+		}
+	})
+
+	t.Run("Join transaction for context derived from transaction", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		derived := WithActor(tc, "alice")
+		tc2, _ := tm.StartTransaction(derived)
+
+		actor := ""
+		err := tm.SubmitTransaction(derived, func(tc TransactionContext) error {
+			actor = ActorOf(tc)
+			_, err := userDataAccess.Delete(tc, 1)
+			return err
+		})
+		cnt, _ := userDataAccess.Count(tc, UserQuery{})
+		if !(err == nil && tc2 == tc && actor == "alice" && cnt == 3) {
+			t.Errorf("Unexpected: %v, %v, %s, %d", err, tc2, actor, cnt)
+		}
+	})
+
+	t.Run("Suspend transaction for context derived from transaction", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(ctx)
+		defer tc.Rollback()
+		tc2, _ := tm.StartTransaction(WithActor(tc, "alice"), WithPropagation(PropagationNotSupported))
+
+		if _, ok := GetTransaction(tc2); ok || ActorOf(tc2) != "alice" {
+			t.Errorf("Should suspend transaction: %v", tc2)
+		}
+	})
+
+	t.Run("Start transaction with isolation and read-only", func(t *testing.T) {
+		tc, err := tm.StartTransaction(ctx, WithIsolation(sql.LevelSerializable), WithReadOnly())
+		if err == nil {
+			defer tc.Rollback()
+		}
+		entities, err := userDataAccess.Query(tc, UserQuery{})
+		if !(err == nil && len(entities) == 4) {
+			t.Errorf("Unexpected: %v, %v", err, entities)
+		}
+	})
+
+	t.Run("Rollback inner changes only when inner callback fails", func(t *testing.T) {
+		defer InitDB(db)
+		err := tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			_, _ = userDataAccess.Delete(tc, 1)
+			innerErr := tm.SubmitTransaction(tc, func(tc TransactionContext) error {
+				_, _ = userDataAccess.Delete(tc, 2)
+				return errors.New("inner error")
+			})
+			if innerErr == nil {
+				t.Error("Should return inner error")
+			}
+			return nil
+		})
+		entities, _ := userDataAccess.Query(ctx, UserQuery{})
+		if !(err == nil && len(entities) == 3 && entities[0].Id == 2) {
+			t.Errorf("Unexpected: %v, %v", err, entities)
+		}
+	})
+
+	t.Run("Rollback inner changes when outer callback fails", func(t *testing.T) {
+		defer InitDB(db)
+		err := tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			innerErr := tm.SubmitTransaction(tc, func(tc TransactionContext) error {
+				_, err := userDataAccess.Delete(tc, 2)
+				return err
+			})
+			if innerErr != nil {
+				return innerErr
+			}
+			return errors.New("outer error")
+		})
+		cnt, _ := userDataAccess.Count(ctx, UserQuery{})
+		if !(err != nil && err.Error() == "outer error" && cnt == 4) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Support save point and nested capabilities", func(t *testing.T) {
+		if !(Supports(tm, CapabilitySavePoint) && Supports(tm, CapabilityNested)) {
+			t.Error("Should support save point")
+		}
+	})
+
+	t.Run("Invoke callbacks after commit and rollback", func(t *testing.T) {
+		var events []string
+		register := func(tc TransactionContext, name string) {
+			tc.OnCommit(func() { events = append(events, name+" committed") })
+			tc.OnRollback(func() { events = append(events, name+" rolled back") })
+		}
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			register(tc, "outer")
+			_ = tm.SubmitTransaction(tc, func(tc TransactionContext) error {
+				register(tc, "inner1")
+				return nil
+			})
+			_ = tm.SubmitTransaction(tc, func(tc TransactionContext) error {
+				register(tc, "inner2")
+				return errors.New("inner error")
+			})
+			if len(events) != 1 {
+				t.Errorf("Should invoke rollback callback of inner2 only: %v", events)
+			}
+			return nil
+		})
+		_ = tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			register(tc, "failed")
+			return errors.New("error")
+		})
+
+		expect := "[inner2 rolled back outer committed inner1 committed failed rolled back]"
+		if actual := fmt.Sprint(events); actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
 }
@@ -0,0 +1,252 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaDialect describes the DDL differences of databases
+// for the QueryDialect, so that one dialect serves a database.
+type schemaDialect struct {
+	types      map[reflect.Kind]string
+	timeType   string
+	bytesType  string
+	primaryKey func(column string, columnType string) string
+	columnsSql string
+	// numbered is true for the placeholders like `$1`.
+	numbered bool
+}
+
+func (d *schemaDialect) ColumnType(fieldType reflect.Type) (string, error) {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == TimeType {
+		return d.timeType, nil
+	}
+	if fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8 {
+		return d.bytesType, nil
+	}
+	if columnType, ok := d.types[fieldType.Kind()]; ok {
+		return columnType, nil
+	}
+	return "", fmt.Errorf("unsupported column type for %s, specify it by the tag `type`", fieldType)
+}
+
+func (d *schemaDialect) PrimaryKey(column string, fieldType reflect.Type) (string, error) {
+	columnType, err := d.ColumnType(fieldType)
+	if HasError(err) {
+		return "", err
+	}
+	return d.primaryKey(column, columnType), nil
+}
+
+func (d *schemaDialect) ColumnsSql() string {
+	return d.columnsSql
+}
+
+func (d *schemaDialect) Placeholder(index int) string {
+	if d.numbered {
+		return "$" + strconv.Itoa(index)
+	}
+	return "?"
+}
+
+func intTypes(int32Type, int64Type string) map[reflect.Kind]string {
+	return map[reflect.Kind]string{
+		reflect.Int: int32Type, reflect.Int8: int32Type, reflect.Int16: int32Type, reflect.Int32: int32Type,
+		reflect.Uint8: int32Type, reflect.Uint16: int32Type, reflect.Uint32: int32Type,
+		reflect.Int64: int64Type, reflect.Uint: int64Type, reflect.Uint64: int64Type,
+	}
+}
+
+func withTypes(types map[reflect.Kind]string, others map[reflect.Kind]string) map[reflect.Kind]string {
+	for kind, columnType := range others {
+		types[kind] = columnType
+	}
+	return types
+}
+
+var sqliteSchema = &schemaDialect{
+	types: withTypes(intTypes("INTEGER", "INTEGER"), map[reflect.Kind]string{
+		reflect.Float32: "REAL", reflect.Float64: "REAL", reflect.Bool: "BOOLEAN", reflect.String: "VARCHAR(255)",
+	}),
+	timeType:  "DATETIME",
+	bytesType: "BLOB",
+	primaryKey: func(column string, _ string) string {
+		return column + " INTEGER PRIMARY KEY AUTOINCREMENT"
+	},
+	columnsSql: "SELECT name FROM pragma_table_info(?)",
+}
+
+var mysqlSchema = &schemaDialect{
+	types: withTypes(intTypes("INT", "BIGINT"), map[reflect.Kind]string{
+		reflect.Float32: "FLOAT", reflect.Float64: "DOUBLE", reflect.Bool: "TINYINT(1)", reflect.String: "VARCHAR(255)",
+	}),
+	timeType:  "DATETIME",
+	bytesType: "BLOB",
+	primaryKey: func(column string, columnType string) string {
+		return column + " " + columnType + " PRIMARY KEY AUTO_INCREMENT"
+	},
+	columnsSql: "SELECT column_name FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ?",
+}
+
+var postgresSchema = &schemaDialect{
+	types: withTypes(intTypes("INTEGER", "BIGINT"), map[reflect.Kind]string{
+		reflect.Float32: "REAL", reflect.Float64: "DOUBLE PRECISION", reflect.Bool: "BOOLEAN", reflect.String: "VARCHAR(255)",
+	}),
+	timeType:  "TIMESTAMP",
+	bytesType: "BYTEA",
+	primaryKey: func(column string, columnType string) string {
+		if columnType == "BIGINT" {
+			return column + " BIGSERIAL PRIMARY KEY"
+		}
+		return column + " SERIAL PRIMARY KEY"
+	},
+	columnsSql: "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1",
+	numbered:   true,
+}
+
+// buildColumnDefinition builds the column by the tags:
+// `type` overrides the column type, and non-pointer fields are NOT NULL.
+func buildColumnDefinition(dialect QueryDialect, fm FieldMetadata) (string, error) {
+	columnType := fm.Field.Tag.Get("type")
+	if columnType == "" {
+		var err error
+		if columnType, err = dialect.ColumnType(fm.Field.Type); HasError(err) {
+			return "", fmt.Errorf("column %s: %w", fm.ColumnName, err)
+		}
+	}
+	definition := fm.ColumnName + " " + columnType
+	if fm.Field.Type.Kind() != reflect.Pointer {
+		definition += " NOT NULL"
+	}
+	return definition, nil
+}
+
+func columnMetasOf(entityType reflect.Type) []FieldMetadata {
+	fieldMetas := BuildFieldMetas(entityType)
+	columnMetas := make([]FieldMetadata, 0, len(fieldMetas))
+	for _, fm := range fieldMetas {
+		if fm.EntityPath == nil {
+			columnMetas = append(columnMetas, fm)
+		}
+	}
+	return columnMetas
+}
+
+// BuildCreateTable builds the CREATE TABLE statement for the entity E,
+// followed by CREATE INDEX statements for the fields tagged by
+// `index` or `unique`, whose value is used as the index name if present.
+// An error is returned for the field whose column type is unsupported.
+func BuildCreateTable[E Entity](dialect QueryDialect) ([]string, error) {
+	entity := *new(E)
+	table := FormatTableByEntity(entity)
+	columnMetas := columnMetasOf(reflect.TypeOf(entity))
+
+	columns := make([]string, len(columnMetas))
+	var indexes []string
+	for i, fm := range columnMetas {
+		var err error
+		if fm.IsId {
+			columns[i], err = dialect.PrimaryKey(fm.ColumnName, fm.Field.Type)
+		} else {
+			columns[i], err = buildColumnDefinition(dialect, fm)
+		}
+		if HasError(err) {
+			return nil, err
+		}
+		indexes = appendIndex(indexes, table, fm, "index", "CREATE INDEX ")
+		indexes = appendIndex(indexes, table, fm, "unique", "CREATE UNIQUE INDEX ")
+	}
+	createTable := "CREATE TABLE " + table + " (" + strings.Join(columns, ", ") + ")"
+	return append([]string{createTable}, indexes...), nil
+}
+
+func appendIndex(indexes []string, table string, fm FieldMetadata, tag string, prefix string) []string {
+	name, ok := fm.Field.Tag.Lookup(tag)
+	if !ok {
+		return indexes
+	}
+	if name == "" {
+		name = "idx_" + table + "_" + fm.ColumnName
+	}
+	return append(indexes, prefix+name+" ON "+table+" ("+fm.ColumnName+")")
+}
+
+type SchemaDiff struct {
+	Table          string
+	MissingTable   bool
+	MissingColumns []string
+	ExtraColumns   []string
+	// Statements are the DDL to create the missing table or columns.
+	Statements []string
+}
+
+// DiffSchema compares the columns of the table in db with the entity E.
+// Extra columns are reported only, since dropping them loses data,
+// and missing columns are added as nullable for the existing rows.
+func DiffSchema[E Entity](ctx context.Context, db *sql.DB, dialect QueryDialect) (SchemaDiff, error) {
+	entity := *new(E)
+	diff := SchemaDiff{Table: FormatTableByEntity(entity)}
+	existing, err := queryColumns(ctx, db, dialect, diff.Table)
+	if HasError(err) {
+		return diff, err
+	}
+	if len(existing) == 0 {
+		diff.MissingTable = true
+		diff.Statements, err = BuildCreateTable[E](dialect)
+		return diff, err
+	}
+	columnMetas := columnMetasOf(reflect.TypeOf(entity))
+	for _, fm := range columnMetas {
+		if !existing[fm.ColumnName] {
+			definition, err := buildColumnDefinition(dialect, fm)
+			if HasError(err) {
+				return diff, err
+			}
+			diff.MissingColumns = append(diff.MissingColumns, fm.ColumnName)
+			definition = strings.TrimSuffix(definition, " NOT NULL")
+			diff.Statements = append(diff.Statements, "ALTER TABLE "+diff.Table+" ADD COLUMN "+definition)
+		}
+		delete(existing, fm.ColumnName)
+	}
+	for column := range existing {
+		diff.ExtraColumns = append(diff.ExtraColumns, column)
+	}
+	sort.Strings(diff.ExtraColumns)
+	return diff, nil
+}
+
+func queryColumns(ctx context.Context, db *sql.DB, dialect QueryDialect, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, dialect.ColumnsSql(), table)
+	if HasError(err) {
+		return nil, err
+	}
+	defer Close(rows)
+	columns := map[string]bool{}
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); HasError(err) {
+			return nil, err
+		}
+		columns[strings.ToLower(column)] = true
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,147 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type AccountEntity struct {
+	Int64Id
+	Username  string  `unique:""`
+	Email     *string `index:"idx_email" type:"VARCHAR(128)"`
+	Balance   *float64
+	Valid     *bool
+	Avatar    []byte
+	CreatedAt *time.Time
+}
+
+type LabelEntity struct {
+	Int64Id
+	Labels []string
+}
+
+type UserV2Entity struct {
+	Int64Id
+	Score *int
+	Memo  *string
+	Level *int
+}
+
+func (e UserV2Entity) GetTableName() string {
+	return "t_user"
+}
+
+func TestBuildCreateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect QueryDialect
+		expect  []string
+	}{
+		{"SQLite", SQLiteQueryDialect, []string{
+			"CREATE TABLE t_account (id INTEGER PRIMARY KEY AUTOINCREMENT, username VARCHAR(255) NOT NULL, email VARCHAR(128), balance REAL, valid BOOLEAN, avatar BLOB NOT NULL, created_at DATETIME)",
+			"CREATE UNIQUE INDEX idx_t_account_username ON t_account (username)",
+			"CREATE INDEX idx_email ON t_account (email)",
+		}},
+		{"MySQL", MySQLQueryDialect, []string{
+			"CREATE TABLE t_account (id BIGINT PRIMARY KEY AUTO_INCREMENT, username VARCHAR(255) NOT NULL, email VARCHAR(128), balance DOUBLE, valid TINYINT(1), avatar BLOB NOT NULL, created_at DATETIME)",
+			"CREATE UNIQUE INDEX idx_t_account_username ON t_account (username)",
+			"CREATE INDEX idx_email ON t_account (email)",
+		}},
+		{"Postgres", PostgresQueryDialect, []string{
+			"CREATE TABLE t_account (id BIGSERIAL PRIMARY KEY, username VARCHAR(255) NOT NULL, email VARCHAR(128), balance DOUBLE PRECISION, valid BOOLEAN, avatar BYTEA NOT NULL, created_at TIMESTAMP)",
+			"CREATE UNIQUE INDEX idx_t_account_username ON t_account (username)",
+			"CREATE INDEX idx_email ON t_account (email)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := BuildCreateTable[AccountEntity](tt.dialect)
+			if !(err == nil && reflect.DeepEqual(actual, tt.expect)) {
+				t.Errorf("\nExpected: %s\nBut got : %s, %v", tt.expect, actual, err)
+			}
+		})
+	}
+
+	t.Run("Reject unsupported column type", func(t *testing.T) {
+		_, err := BuildCreateTable[LabelEntity](SQLiteQueryDialect)
+		expect := "column labels: unsupported column type for []string, specify it by the tag `type`"
+		if err == nil || err.Error() != expect {
+			t.Errorf("\nExpected: %s\nBut got : %v", expect, err)
+		}
+	})
+
+	t.Run("Render placeholders by dialect", func(t *testing.T) {
+		if !(SQLiteQueryDialect.Placeholder(2) == "?" && PostgresQueryDialect.Placeholder(2) == "$2") {
+			t.Errorf("Unexpected: %s, %s", SQLiteQueryDialect.Placeholder(2), PostgresQueryDialect.Placeholder(2))
+		}
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer InitDB(db)
+	defer Disconnect(db)
+	_, _ = db.Exec("drop table if exists schema_migrations; drop table if exists t_account")
+	ctx := context.Background()
+	migrator := NewMigrator(db, WithQueryDialect(SQLiteQueryDialect))
+	createAccount, _ := BuildCreateTable[AccountEntity](SQLiteQueryDialect)
+
+	t.Run("Apply pending migrations in order", func(t *testing.T) {
+		migrations := []Migration{
+			{Version: 2, Name: "add level", Statements: []string{"ALTER TABLE t_user ADD COLUMN level INTEGER"}},
+			{Version: 1, Name: "create account", Statements: createAccount},
+		}
+		done, err := migrator.Migrate(ctx, migrations...)
+		again, _ := migrator.Migrate(ctx, migrations...)
+		applied, _ := migrator.Applied(ctx)
+		if !(err == nil && reflect.DeepEqual(done, []int64{1, 2}) && len(again) == 0 && len(applied) == 2) {
+			t.Errorf("Unexpected: %v, %v, %v, %v", err, done, again, applied)
+		}
+	})
+
+	t.Run("Rollback failed migration", func(t *testing.T) {
+		done, err := migrator.Migrate(ctx, Migration{Version: 3, Name: "invalid", Statements: []string{
+			"CREATE TABLE t_tmp (id INTEGER)", "INVALID SQL",
+		}})
+		applied, _ := migrator.Applied(ctx)
+		diff, _ := DiffSchema[UserV2Entity](ctx, db, SQLiteQueryDialect)
+		if !(err != nil && len(done) == 0 && len(applied) == 2 && len(diff.MissingColumns) == 0) {
+			t.Errorf("Unexpected: %v, %v, %v, %v", err, done, applied, diff)
+		}
+	})
+
+	t.Run("Diff schema with entities", func(t *testing.T) {
+		_, _ = db.Exec("drop table if exists t_account")
+		diff, err := DiffSchema[UserEntity](ctx, db, SQLiteQueryDialect)
+		accountDiff, _ := DiffSchema[AccountEntity](ctx, db, SQLiteQueryDialect)
+		if !(err == nil && diff.Table == "t_user" && len(diff.MissingColumns) == 0 &&
+			reflect.DeepEqual(diff.ExtraColumns, []string{"level"}) &&
+			accountDiff.MissingTable && len(accountDiff.Statements) == 3) {
+			t.Errorf("Unexpected: %v, %v, %v", err, diff, accountDiff)
+		}
+	})
+
+	t.Run("Build statements for missing columns", func(t *testing.T) {
+		InitDB(db)
+		diff, _ := DiffSchema[UserV2Entity](ctx, db, SQLiteQueryDialect)
+		expect := []string{"ALTER TABLE t_user ADD COLUMN level INTEGER"}
+		if !(reflect.DeepEqual(diff.MissingColumns, []string{"level"}) && reflect.DeepEqual(diff.Statements, expect)) {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, diff.Statements)
+		}
+	})
+}
@@ -127,6 +127,24 @@ func TestSQLite(t *testing.T) {
 		}
 	})
 
+	t.Run("Count By Query with EXISTS", func(t *testing.T) {
+		tests := []struct {
+			query  UserQuery
+			expect int64
+		}{
+			{UserQuery{RoleExists: &RoleQuery{}}, 3},
+			{UserQuery{RoleExists: &RoleQuery{Id: P(2)}}, 2},
+			{UserQuery{RoleNotExists: &RoleQuery{}}, 1},
+			{UserQuery{CreatedRoleExists: &RoleQuery{}}, 2},
+		}
+		for _, tt := range tests {
+			cnt, err := userDataAccess.Count(ctx, &tt.query)
+			if err != nil || cnt != tt.expect {
+				t.Errorf("\nExpected: %d\nBut got : %d, %v", tt.expect, cnt, err)
+			}
+		}
+	})
+
 	t.Run("Page By Query", func(t *testing.T) {
 		userQuery := UserQuery{
 			PageQuery: PageQuery{PageSize: P(2)},
@@ -237,6 +255,24 @@ func TestSQLite(t *testing.T) {
 		_ = tc.Rollback()
 	})
 
+	t.Run("Reject invalid query", func(t *testing.T) {
+		Config.MaxItems = 1000
+		defer func() { Config.MaxItems = 0 }()
+		query := UserQuery{PageQuery: PageQuery{PageSize: P(-1), Sort: P("name")}, IdIn: P(make([]int, 50000))}
+		_, err1 := userDataAccess.Query(ctx, &query)
+		_, err2 := userDataAccess.Count(ctx, &query)
+		_, err3 := userDataAccess.DeleteByQuery(ctx, &query)
+		_, err4 := userDataAccess.PatchByQuery(ctx, UserEntity{Memo: P("Memo")}, &query)
+
+		expect := "invalid query: PageSize must be at least 0; IdIn must have at most 1000 items; Sort has unknown column: name"
+		var validationError *ValidationError
+		for _, err := range []error{err1, err2, err3, err4} {
+			if !errors.As(err, &validationError) || err.Error() != expect {
+				t.Errorf("\nExpected: %s\nBut got : %v", expect, err)
+			}
+		}
+	})
+
 	t.Run("Related Query: Query users with related roles", func(t *testing.T) {
 		userQuery := UserQuery{WithRoles: &RoleQuery{}}
 		users, err := userDataAccess.Query(ctx, &userQuery)
@@ -0,0 +1,18 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import "context"
+
+// TableResolver resolves the table name for the current request,
+// e.g., `t_user` -> `tenant_42.t_user` or `t_order` -> `t_order_2024`.
+// It is configured by WithTableResolver.
+type TableResolver func(ctx context.Context, table string) string
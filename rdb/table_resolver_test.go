@@ -0,0 +1,92 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	. "github.com/doytowin/goooqo/test"
+	"testing"
+)
+
+type shardKey struct{}
+
+func shardResolver(ctx context.Context, table string) string {
+	if shard, ok := ctx.Value(shardKey{}).(string); ok && table == "t_user" {
+		return table + "_" + shard
+	}
+	return table
+}
+
+func TestTableResolver(t *testing.T) {
+	options := BuildOptions(WithTableResolver(func(ctx context.Context, table string) string {
+		return "tenant_42." + table
+	}))
+	em := buildEntityMetadata[UserEntity]()
+	s := newScope(context.Background(), options)
+
+	t.Run("Resolve tables in subquery", func(t *testing.T) {
+		actual, _ := em.buildDelete(s, UserQuery{Role: &RoleQuery{Id: P(1)}})
+		expect := "DELETE FROM tenant_42.t_user WHERE id IN (SELECT user_id FROM tenant_42.a_user_and_role WHERE role_id IN (SELECT id FROM tenant_42.t_role WHERE id = ?))"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Resolve tables in correlated columns", func(t *testing.T) {
+		actual, _ := em.buildCount(s, UserQuery{RoleExists: &RoleQuery{}})
+		expect := "SELECT count(0) FROM tenant_42.t_user WHERE EXISTS (SELECT 1 FROM tenant_42.a_user_and_role " +
+			"WHERE tenant_42.a_user_and_role.user_id = tenant_42.t_user.id AND EXISTS (SELECT 1 FROM tenant_42.t_role " +
+			"WHERE tenant_42.t_role.id = tenant_42.a_user_and_role.role_id))"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Resolve tables for insert and update", func(t *testing.T) {
+		createStr, _ := em.buildCreate(s, UserEntity{})
+		updateStr, _ := em.buildPatchById(s, UserEntity{Score: P(1)})
+		actual := createStr + "; " + updateStr
+		expect := "INSERT INTO tenant_42.t_user (score, memo) VALUES (?, ?); UPDATE tenant_42.t_user SET score = ? WHERE id = ?"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Keep the keywords of the columns unchanged", func(t *testing.T) {
+		actual, _ := em.buildCount(s, UserQuery{MemoNull: P(true)})
+		expect := "SELECT count(0) FROM tenant_42.t_user WHERE memo IS NULL"
+		if actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+}
+
+func TestTableResolverWithSqlite(t *testing.T) {
+	db := Connect()
+	InitDB(db)
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_user_2024;
+create table t_user_2024 as select * from t_user where score > 60;`)
+
+	tm := NewTransactionManager(db, WithTableResolver(shardResolver))
+	userDataAccess := NewTxDataAccess[UserEntity](tm)
+
+	ctx := context.Background()
+	shardCtx := context.WithValue(ctx, shardKey{}, "2024")
+
+	cnt, err := userDataAccess.Count(ctx, UserQuery{})
+	shardCnt, _ := userDataAccess.Count(shardCtx, UserQuery{})
+	existsCnt, _ := userDataAccess.Count(shardCtx, UserQuery{RoleExists: &RoleQuery{}})
+	if !(err == nil && cnt == 4 && shardCnt == 2 && existsCnt == 2) {
+		t.Errorf("Unexpected: %v, %d, %d, %d", err, cnt, shardCnt, existsCnt)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"reflect"
+)
+
+// resolveTenant returns the tenant to be applied for E,
+// ok is false when E has no tenant field or the tenant is bypassed.
+func (em *EntityMetadata[E]) resolveTenant(ctx context.Context) (tenant any, ok bool, err error) {
+	if em.tenantMeta == nil {
+		return nil, false, nil
+	}
+	tenant, bypass, err := ResolveTenant(ctx)
+	return tenant, err == nil && !bypass, err
+}
+
+// withTenantById appends the tenant condition to
+// the statement ending with the where clause for id.
+func (em *EntityMetadata[E]) withTenantById(ctx context.Context, sqlStr string, args []any) (string, []any, error) {
+	tenant, ok, err := em.resolveTenant(ctx)
+	if !ok {
+		return sqlStr, args, err
+	}
+	return sqlStr + " AND " + em.tenantMeta.ColumnName + " = ?", append(args, tenant), nil
+}
+
+// fillTenant sets the tenant from ctx to the tenant field of the entities.
+func (em *EntityMetadata[E]) fillTenant(ctx context.Context, entities ...*E) error {
+	tenant, ok, err := em.resolveTenant(ctx)
+	if !ok {
+		return err
+	}
+	for _, entity := range entities {
+		AssignTenant(reflect.ValueOf(entity).Elem().FieldByName(em.tenantMeta.Field.Name), tenant)
+	}
+	return nil
+}
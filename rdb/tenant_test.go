@@ -0,0 +1,185 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2024, DoytoWin, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package rdb
+
+import (
+	"context"
+	. "github.com/doytowin/goooqo/core"
+	"testing"
+)
+
+type TenantUserEntity struct {
+	Int64Id
+	TenantId *string `tenant:""`
+	Score    *int
+	Roles    []TenantRoleEntity `entitypath:"tenant_user,tenant_role"`
+}
+
+func (e TenantUserEntity) GetTableName() string {
+	return "t_tenant_user"
+}
+
+type TenantUserQuery struct {
+	PageQuery
+	ScoreLt    *int
+	ScoreGtAvg *TenantUserQuery `select:"avg(score)" from:"TenantUserEntity"`
+	Role       *TenantRoleQuery `entitypath:"tenant_user,tenant_role"`
+	RoleExists *TenantRoleQuery `exists:"tenant_user,tenant_role"`
+	WithRoles  *TenantRoleQuery
+}
+
+type TenantRoleEntity struct {
+	Int64Id
+	TenantId *string `tenant:""`
+	Name     *string
+}
+
+func (e TenantRoleEntity) GetTableName() string {
+	return "t_tenant_role"
+}
+
+type TenantRoleQuery struct {
+	PageQuery
+	Id *int
+}
+
+type BuiltScoreQuery struct {
+	PageQuery
+}
+
+func (q BuiltScoreQuery) BuildConditions() ([]string, []any) {
+	return []string{"score > ?"}, []any{60}
+}
+
+func TestTenant(t *testing.T) {
+	db := Connect()
+	defer Disconnect(db)
+	_, _ = db.Exec(`drop table if exists t_tenant_user;
+create table t_tenant_user(id integer constraint tenant_user_pk primary key autoincrement, tenant_id varchar(32), score integer);
+INSERT INTO t_tenant_user(tenant_id, score) VALUES ('t1', 85), ('t1', 40), ('t2', 55), ('t2', 62), ('t2', 70);
+drop table if exists t_tenant_role;
+create table t_tenant_role(id integer constraint tenant_role_pk primary key autoincrement, tenant_id varchar(32), name varchar(32));
+INSERT INTO t_tenant_role(tenant_id, name) VALUES ('t1', 'admin'), ('t2', 'admin');
+drop table if exists a_tenant_user_and_tenant_role;
+create table a_tenant_user_and_tenant_role(tenant_user_id integer, tenant_role_id integer);
+INSERT INTO a_tenant_user_and_tenant_role(tenant_user_id, tenant_role_id) VALUES (1, 1), (1, 2);`)
+	ctx := context.Background()
+	t1 := WithTenant(ctx, "t1")
+	t2 := WithTenant(ctx, "t2")
+	tm := NewTransactionManager(db)
+	dataAccess := NewTxDataAccess[TenantUserEntity](tm)
+	_ = NewTxDataAccess[TenantRoleEntity](tm)
+
+	t.Run("Build select with tenant condition", func(t *testing.T) {
+		em := buildEntityMetadata[TenantUserEntity]()
+		actual, args := em.buildSelect(newScope(t1, BuildOptions()), TenantUserQuery{ScoreLt: P(60)})
+		expect := "SELECT id, tenant_id, score FROM t_tenant_user WHERE score < ? AND tenant_id = ?"
+		if !(actual == expect && len(args) == 2 && args[1] == "t1") {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Filter query and count by tenant", func(t *testing.T) {
+		users, err := dataAccess.Query(t2, TenantUserQuery{ScoreLt: P(65)})
+		cnt, _ := dataAccess.Count(t1, TenantUserQuery{})
+		if !(err == nil && len(users) == 2 && cnt == 2) {
+			t.Errorf("Unexpected: %v, %v, %d", err, users, cnt)
+		}
+	})
+
+	t.Run("Return nothing for entity of other tenant", func(t *testing.T) {
+		user, err := dataAccess.Get(t1, 3)
+		cnt, _ := dataAccess.Delete(t1, 4)
+		if !(err == nil && user == nil && cnt == 0) {
+			t.Errorf("Unexpected: %v, %v, %d", err, user, cnt)
+		}
+	})
+
+	t.Run("Fail without tenant in context", func(t *testing.T) {
+		_, err := dataAccess.Query(ctx, TenantUserQuery{})
+		if err != ErrTenantMissing {
+			t.Errorf("\nExpected: %s\nBut got : %v", ErrTenantMissing, err)
+		}
+	})
+
+	t.Run("Bypass tenant for admin", func(t *testing.T) {
+		cnt, err := dataAccess.Count(WithoutTenant(ctx), TenantUserQuery{})
+		if !(err == nil && cnt == 5) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Set tenant on create and update", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(t2)
+		defer tc.Rollback()
+		entity := TenantUserEntity{TenantId: P("t1"), Score: P(90)}
+		_, err := dataAccess.Create(tc, &entity)
+		cnt, _ := dataAccess.Update(tc, TenantUserEntity{Int64Id: NewInt64Id(1), TenantId: P("t2")})
+		total, _ := dataAccess.Count(tc, TenantUserQuery{})
+		if !(err == nil && *entity.TenantId == "t2" && cnt == 0 && total == 4) {
+			t.Errorf("Unexpected: %v, %v, %d, %d", err, entity, cnt, total)
+		}
+	})
+
+	t.Run("Patch by query within tenant", func(t *testing.T) {
+		tc, _ := tm.StartTransaction(t1)
+		defer tc.Rollback()
+		cnt, err := dataAccess.PatchByQuery(tc, TenantUserEntity{Score: P(0)}, TenantUserQuery{})
+		if !(err == nil && cnt == 2) {
+			t.Errorf("Unexpected: %v, %d", err, cnt)
+		}
+	})
+
+	t.Run("Skip related entities of other tenant", func(t *testing.T) {
+		users, err := dataAccess.Query(t1, TenantUserQuery{ScoreLt: P(90), WithRoles: &TenantRoleQuery{}})
+		byPath, _ := dataAccess.Count(t1, TenantUserQuery{Role: &TenantRoleQuery{Id: P(2)}})
+		byExists, _ := dataAccess.Count(t1, TenantUserQuery{RoleExists: &TenantRoleQuery{Id: P(2)}})
+		if !(err == nil && len(users) == 2 && len(users[0].Roles) == 1 && users[0].Roles[0].Id == 1 &&
+			byPath == 0 && byExists == 0) {
+			t.Errorf("Unexpected: %v, %v, %d, %d", err, users, byPath, byExists)
+		}
+	})
+
+	t.Run("Build conditions by QueryBuilder for table without tenant", func(t *testing.T) {
+		em := buildEntityMetadata[GoodUserEntity]()
+		actual, args := em.buildCount(newScope(t1, BuildOptions()), BuiltScoreQuery{})
+		expect := "SELECT count(0) FROM v_good_user WHERE score > ?"
+		if !(actual == expect && len(args) == 1 && args[0] == 60) {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Build subquery with tenant condition", func(t *testing.T) {
+		em := buildEntityMetadata[TenantUserEntity]()
+		actual, args := em.buildSelect(newScope(t1, BuildOptions()), TenantUserQuery{ScoreGtAvg: &TenantUserQuery{}})
+		expect := "SELECT id, tenant_id, score FROM t_tenant_user " +
+			"WHERE score > (SELECT avg(score) FROM t_tenant_user WHERE tenant_id = ?) AND tenant_id = ?"
+		if !(actual == expect && len(args) == 2 && args[0] == "t1") {
+			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
+		}
+	})
+
+	t.Run("Capture changes with tenant of context derived from transaction", func(t *testing.T) {
+		feed := NewChangeFeed()
+		var records []ChangeRecord
+		defer feed.Subscribe(func(ctx context.Context, record ChangeRecord) {
+			records = append(records, record)
+		})()
+		capturedDataAccess := NewChangeCapturedDataAccess(dataAccess, feed)
+		err := tm.SubmitTransaction(ctx, func(tc TransactionContext) error {
+			_, err := capturedDataAccess.Create(WithTenant(tc, "t1"), &TenantUserEntity{Score: P(95)})
+			return err
+		})
+		if !(err == nil && len(records) == 1 && *records[0].After[0].(TenantUserEntity).TenantId == "t1") {
+			t.Errorf("Unexpected: %v, %v", err, records)
+		}
+	})
+}
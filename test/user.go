@@ -58,6 +58,15 @@ type UserQuery struct {
 		)
 	)*/
 	Perm *PermQuery `entitypath:"user,role,perm"`
+
+	/**
+	EXISTS (SELECT 1 FROM a_user_and_role WHERE a_user_and_role.user_id = t_user.id
+	AND EXISTS (SELECT 1 FROM t_role WHERE t_role.id = a_user_and_role.role_id AND ...))
+	*/
+	RoleExists        *RoleQuery `exists:"user,role"`
+	RoleNotExists     *RoleQuery `exists:"user,role"`
+	CreatedRoleExists *RoleQuery `exists:"user,role" foreignField:"createUserId"`
+	PermExists        *PermQuery `exists:"user,role,perm"`
 }
 
 var UserDataAccess TxDataAccess[UserEntity]
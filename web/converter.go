@@ -65,6 +65,51 @@ func init() {
 	RegisterConverter(reflect.PointerTo(reflect.TypeOf("")), func(v []string) (any, error) {
 		return &v[0], nil
 	})
+
+	RegisterRangeConverter(strconv.Atoi)
+	RegisterRangeConverter(func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	})
+	RegisterRangeConverter(func(s string) (string, error) {
+		return s, nil
+	})
+	RegisterRangeConverter(core.ParseTime)
+
+	RegisterConverter(core.TimeType, func(v []string) (any, error) {
+		return core.ParseTime(v[0])
+	})
+	RegisterConverter(reflect.PointerTo(core.TimeType), func(v []string) (any, error) {
+		t, err := core.ParseTime(v[0])
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	})
+}
+
+// RegisterRangeConverter registers the converter for *core.Range[T],
+// which binds a parameter like `2024-01-01,2024-02-01`,
+// and an empty side leaves the bound nil.
+func RegisterRangeConverter[T any](parse func(string) (T, error)) {
+	RegisterConverter(reflect.TypeOf(&core.Range[T]{}), func(params []string) (any, error) {
+		if len(params) == 1 {
+			params = strings.SplitN(params[0], ",", 2)
+		}
+		r := &core.Range[T]{}
+		bounds := []**T{&r.From, &r.To}
+		for i := 0; i < len(params) && i < len(bounds); i++ {
+			s := strings.TrimSpace(params[i])
+			if s == "" {
+				continue
+			}
+			v, err := parse(s)
+			if err != nil {
+				return nil, err
+			}
+			*bounds[i] = &v
+		}
+		return r, nil
+	})
 }
 
 func ResolveQuery(queryMap url.Values, query any) {
@@ -101,7 +146,12 @@ func resolveParam(elem reflect.Value, fieldName string) reflect.Value {
 func convertAndSet(field reflect.Value, v []string) {
 	log.Debug("field.Type: ", field.Type())
 	fieldType := field.Type()
-	v0, err := converterMap[fieldType](v)
+	converter, ok := converterMap[fieldType]
+	if !ok {
+		log.Warn("Converter not found: ", fieldType)
+		return
+	}
+	v0, err := converter(v)
 	if core.NoError(err) || v0 != nil {
 		field.Set(reflect.ValueOf(v0))
 	}
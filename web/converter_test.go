@@ -12,9 +12,11 @@ package web
 
 import (
 	"encoding/json"
+	"github.com/doytowin/goooqo/core"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestConverter(t *testing.T) {
@@ -72,7 +74,10 @@ func TestConverter(t *testing.T) {
 			Unit *Unit `json:"unit,omitempty"`
 		}
 		type qo struct {
-			Size *SizeQuery `json:"size,omitempty"`
+			Size           *SizeQuery             `json:"size,omitempty"`
+			ScoreBetween   *core.Range[int]       `json:"scoreBetween,omitempty"`
+			CreatedBetween *core.Range[time.Time] `json:"createdBetween,omitempty"`
+			CreatedAt      *time.Time             `json:"createdAt,omitempty"`
 		}
 		type args struct {
 			queryMap url.Values
@@ -89,6 +94,16 @@ func TestConverter(t *testing.T) {
 				args{url.Values{"Size.HLt": {"20"}, "Size.HGe": {"10"}}, qo{}}},
 			{"Level Three of Nested Parameters", `{"size":{"unit":{"name":"cm"}}}`,
 				args{url.Values{"Size.Unit.Name": {"cm"}}, qo{}}},
+			{"Range Parameters", `{"createdBetween":{"from":"2024-01-01T00:00:00Z","to":"2024-02-01T00:00:00Z"}}`,
+				args{url.Values{"createdBetween": {"2024-01-01,2024-02-01"}}, qo{}}},
+			{"Time Parameter", `{"createdAt":"2024-01-01T08:00:00Z"}`,
+				args{url.Values{"createdAt": {"2024-01-01 08:00:00"}}, qo{}}},
+			{"Invalid Time Parameter", `{}`,
+				args{url.Values{"createdAt": {"yesterday"}}, qo{}}},
+			{"Range Parameters with Lower Bound", `{"scoreBetween":{"from":60}}`,
+				args{url.Values{"scoreBetween": {"60,"}}, qo{}}},
+			{"Range Parameters with Upper Bound", `{"scoreBetween":{"to":80}}`,
+				args{url.Values{"scoreBetween": {",80"}}, qo{}}},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
@@ -101,4 +116,16 @@ func TestConverter(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Resolve relative time", func(t *testing.T) {
+		type qo struct {
+			CreatedGe *time.Time
+		}
+		query := qo{}
+		ResolveQuery(url.Values{"createdGe": {"now-7d"}}, &query)
+		expect := time.Now().AddDate(0, 0, -7)
+		if query.CreatedGe == nil || query.CreatedGe.Before(expect.Add(-time.Minute)) || query.CreatedGe.After(expect) {
+			t.Errorf("\nExpected: %s\nBut got : %v", expect, query.CreatedGe)
+		}
+	})
 }
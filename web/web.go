@@ -12,6 +12,7 @@ package web
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	. "github.com/doytowin/goooqo/core"
 	"io"
@@ -120,6 +121,10 @@ func (s *restService[E, Q]) process(request *http.Request, id string) (any, erro
 
 func writeResult(writer http.ResponseWriter, err error, data any) {
 	response := Response{Data: data, Success: NoError(err), Error: ReadError(err)}
+	var validationError *ValidationError
+	if errors.As(err, &validationError) {
+		response.Data, response.Errors = nil, validationError.Errors
+	}
 	var bytes []byte
 	if os.Getenv("web_intent") == "true" {
 		bytes, err = json.MarshalIndent(response, "", "  ")
@@ -128,6 +133,9 @@ func writeResult(writer http.ResponseWriter, err error, data any) {
 	}
 	if NoError(err) {
 		writer.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if validationError != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+		}
 		_, _ = writer.Write(bytes)
 	}
 }
@@ -236,4 +236,16 @@ func TestWeb(t *testing.T) {
 			t.Errorf("\nExpected: %s\nBut got : %s", expect, actual)
 		}
 	})
+	t.Run("Return 400 for invalid query", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/user/?PageSize=-1&MemoLike=%20&Sort=name", nil)
+		rs.ServeHTTP(writer, request)
+
+		actual := writer.Body.String()
+		expect := `{"success":false,"error":"invalid query: PageSize must be at least 0; MemoLike must not be blank; Sort has unknown column: name",` +
+			`"errors":[{"field":"PageSize","message":"must be at least 0"},{"field":"MemoLike","message":"must not be blank"},{"field":"Sort","message":"has unknown column: name"}]}`
+		if writer.Code != 400 || actual != expect {
+			t.Errorf("\nExpected: %s\nBut got : %d %s", expect, writer.Code, actual)
+		}
+	})
 }